@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/inngest/event-schemas/pkg/compat"
+)
+
+func runDiff(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	breaking := fs.String("breaking", "any", "which changes are breaking:  any, consumers or producers")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return usageErrorf("expected an old and a new cue file")
+	}
+	var o compat.Options
+	switch *breaking {
+	case "any":
+	case "consumers":
+		o.Viewpoint = compat.Consumers
+	case "producers":
+		o.Viewpoint = compat.Producers
+	default:
+		return usageErrorf("unknown --breaking: %s", *breaking)
+	}
+
+	old, err := readInput(e, args[0])
+	if err != nil {
+		return err
+	}
+	new, err := readInput(e, args[1])
+	if err != nil {
+		return err
+	}
+
+	report, err := compat.CompareString(ctx, string(old), string(new), o)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		byt, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(e.stdout, string(byt))
+	} else if len(report.Changes) > 0 {
+		fmt.Fprintln(e.stdout, report.String())
+	}
+
+	if changes := report.BreakingChanges(); len(changes) > 0 {
+		return fmt.Errorf("%w: %d breaking change(s)", errBreaking, len(changes))
+	}
+	return nil
+}
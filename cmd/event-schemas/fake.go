@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/inngest/event-schemas/pkg/fakedata"
+)
+
+func runFake(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("fake", flag.ContinueOnError)
+	n := fs.Int("n", 1, "the number of events to generate")
+	defs := fs.String("defs", "", "a directory of custom event definitions")
+	version := fs.String("version", "", "the version of the registered event")
+	seed := fs.Int64("seed", 0, "a seed for reproducible output;  each event uses the next seed")
+	now := fs.String("now", "", "the RFC3339 time which event timestamps precede;  defaults to the current time, or a fixed epoch with --seed")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageErrorf("expected an event name")
+	}
+	if *n < 1 {
+		return usageErrorf("-n must be at least 1")
+	}
+	seeded := false
+	fs.Visit(func(f *flag.Flag) {
+		seeded = seeded || f.Name == "seed"
+	})
+
+	// Seeded events are relative to a fixed time, so that the same seed
+	// always generates identical events.
+	var at time.Time
+	if seeded {
+		at = fakedata.Epoch
+	}
+	if *now != "" {
+		if at, err = time.Parse(time.RFC3339, *now); err != nil {
+			return usageErrorf("invalid --now: %s", err)
+		}
+	}
+
+	evt, err := lookup(ctx, *defs, args[0], *version)
+	if err != nil {
+		return err
+	}
+
+	// Write each event as a single line of JSON.
+	for i := 0; i < *n; i++ {
+		o := fakedata.DefaultOptions
+		o.Seed = time.Now().UnixNano()
+		if seeded {
+			o.Seed = *seed + int64(i)
+		}
+		o.Now = at
+		fake, err := fakedata.FakeEvent(ctx, evt, o)
+		if err != nil {
+			return err
+		}
+		byt, err := json.Marshal(fake)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(e.stdout, string(byt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/events"
+	"github.com/inngest/event-schemas/events/marshalling/jsonschema"
+	"github.com/inngest/event-schemas/events/marshalling/typescript"
+	"github.com/inngest/event-schemas/events/parse"
+	"github.com/inngest/event-schemas/pkg/cueutil"
+)
+
+const (
+	langTypeScript = "ts"
+	langJSONSchema = "jsonschema"
+	langCue        = "cue"
+
+	dialectOpenAPI = "openapi"
+	dialect202012  = "2020-12"
+)
+
+func runGenerate(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	lang := fs.String("lang", langTypeScript, "the output language:  ts, jsonschema or cue")
+	defs := fs.String("defs", "", "a directory of custom event definitions")
+	version := fs.String("version", "", "the version of the registered event")
+	dialect := fs.String("dialect", dialectOpenAPI, "the JSON schema dialect:  openapi or 2020-12")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageErrorf("expected a cue file or event name")
+	}
+	switch *lang {
+	case langTypeScript, langJSONSchema, langCue:
+	default:
+		return usageErrorf("unknown language: %s", *lang)
+	}
+	var o jsonschema.Options
+	switch *dialect {
+	case dialectOpenAPI:
+	case dialect202012:
+		o.Dialect = jsonschema.Dialect202012
+	default:
+		return usageErrorf("unknown dialect: %s", *dialect)
+	}
+
+	var out string
+	if isCueFile(args[0]) {
+		out, err = generateFile(e, args[0], *lang, o)
+	} else {
+		out, err = generateEvent(ctx, *defs, args[0], *version, *lang, o)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(e.stdout, out)
+	return err
+}
+
+// generateFile generates the given language for a cue file.
+func generateFile(e env, file, lang string, o jsonschema.Options) (string, error) {
+	byt, err := readInput(e, file)
+	if err != nil {
+		return "", err
+	}
+
+	r := &cue.Runtime{}
+	inst, err := r.Compile(file, byt)
+	if err != nil {
+		return "", fmt.Errorf("error compiling %s: %w", file, err)
+	}
+
+	switch lang {
+	case langTypeScript:
+		return typescript.MarshalCueValue(inst.Value())
+	case langJSONSchema:
+		schemas, err := jsonschema.MarshalString(string(byt))
+		if err != nil {
+			return "", err
+		}
+		if len(schemas.All) == 0 {
+			// Files without definitions describe a single value.
+			schema, err := jsonschema.MarshalCueValue(inst.Value())
+			if err != nil {
+				return "", err
+			}
+			return marshalJSON(jsonschema.Convert(schema, o))
+		}
+		return marshalJSON(definitionsDocument(schemas, o))
+	default:
+		return cueutil.ASTToSyntax(inst.Value().Syntax(cue.Docs(true), cue.Optional(true)))
+	}
+}
+
+// definitionsDocument returns a document containing a schema for each
+// definition within a cue file:  OpenAPI components, or "$defs" within a
+// draft 2020-12 document.
+func definitionsDocument(schemas jsonschema.Schemas, o jsonschema.Options) map[string]interface{} {
+	all := map[string]interface{}{}
+	for name, schema := range schemas.All {
+		all[name] = schema
+	}
+	if o.Dialect == jsonschema.DialectOpenAPI {
+		return map[string]interface{}{
+			"components": map[string]interface{}{"schemas": all},
+		}
+	}
+	return jsonschema.Convert(map[string]interface{}{"$defs": all}, o)
+}
+
+// generateEvent returns the given language for a registered event.
+func generateEvent(ctx context.Context, defs, name, version, lang string, o jsonschema.Options) (string, error) {
+	evt, err := lookup(ctx, defs, name, version)
+	if err != nil {
+		return "", err
+	}
+
+	switch lang {
+	case langTypeScript:
+		return evt.TypeScript, nil
+	case langJSONSchema:
+		if o.Dialect == jsonschema.Dialect202012 {
+			return marshalJSON(evt.JSONSchema())
+		}
+		return marshalJSON(evt.Schema)
+	default:
+		return evt.Cue, nil
+	}
+}
+
+// registry returns the embedded event registry, or a registry of the custom
+// definitions within dir if given.
+func registry(ctx context.Context, dir string) (*events.Registry, error) {
+	if dir == "" {
+		return events.DefaultRegistry, nil
+	}
+	evts, err := parse.ParseDir(ctx, dir, parse.Options{IncludeDef: true})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing definitions: %w", err)
+	}
+	return events.NewRegistry(evts)
+}
+
+// lookup returns the registered event with the given name and version, or
+// the latest version if no version is given.
+func lookup(ctx context.Context, defs, name, version string) (events.Event, error) {
+	r, err := registry(ctx, defs)
+	if err != nil {
+		return events.Event{}, err
+	}
+
+	evt, ok := r.Get(name)
+	if version != "" {
+		evt, ok = r.GetVersion(name, version)
+	}
+	if !ok {
+		return events.Event{}, fmt.Errorf("%w: %s", events.ErrUnknownEvent, name)
+	}
+	return evt, nil
+}
+
+func marshalJSON(v interface{}) (string, error) {
+	byt, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(byt), nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/inngest/event-schemas/events/marshalling/jsonschema"
+)
+
+func runImport(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	formats := fs.Bool("formats", false, "import well-known formats as patterns")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageErrorf("expected a JSON schema file")
+	}
+
+	// Other documents referenced by the schema are resolved from the
+	// schema's directory, either by relative path or by $id.
+	dir, file := filepath.Split(args[0])
+	if dir == "" {
+		dir = "."
+	}
+	docs, err := jsonschema.ReadDocuments(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+
+	imported, err := jsonschema.Import(filepath.ToSlash(file), docs, jsonschema.ImportOptions{Formats: *formats})
+	if err != nil {
+		return fmt.Errorf("error importing %s: %w", args[0], err)
+	}
+	for _, w := range imported.Warnings {
+		fmt.Fprintf(e.stderr, "warning: %s\n", w)
+	}
+	_, err = fmt.Fprint(e.stdout, imported.Cue)
+	return err
+}
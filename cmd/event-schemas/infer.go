@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/inngest/event-schemas/events/marshalling/fromjson"
+)
+
+func runInfer(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("infer", flag.ContinueOnError)
+	counts := fs.Bool("counts", false, "print the number of samples containing each field instead of the type")
+	formats := fs.Bool("formats", false, "constrain strings in well-known formats, eg. UUIDs and timestamps")
+	enums := fs.Bool("enums", false, "infer low-cardinality string fields as enums")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageErrorf("expected a JSONL file of samples")
+	}
+
+	// Stream samples from the file, as captured traffic may be large.
+	in := e.stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	inferred, err := fromjson.FromSamplesWithOptions(in, fromjson.Options{
+		Formats: *formats,
+		Enums:   *enums,
+	})
+	if err != nil {
+		return fmt.Errorf("error inferring %s: %w", args[0], err)
+	}
+
+	if !*counts {
+		_, err = fmt.Fprintln(e.stdout, inferred.Cue)
+		return err
+	}
+
+	paths := make([]string, 0, len(inferred.Counts))
+	for path := range inferred.Counts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(e.stdout, "%s\t%d/%d\n", path, inferred.Counts[path], inferred.Samples)
+	}
+	return nil
+}
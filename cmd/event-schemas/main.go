@@ -0,0 +1,194 @@
+// Command event-schemas generates types for, validates, imports, infers, fakes
+// and diffs event schemas from the command line.
+//
+// Usage:
+//
+//	event-schemas generate --lang ts|jsonschema|cue [--dialect openapi|2020-12] <file.cue | event-name>
+//	event-schemas validate <event.json>
+//	event-schemas import [--formats] <schema.json>
+//	event-schemas infer [--counts] [--formats] [--enums] <samples.jsonl>
+//	event-schemas fake <event-name> [-n 10] [--seed 1] [--now 2022-01-01T00:00:00Z]
+//	event-schemas merge <a.cue> <b.cue> [...]
+//	event-schemas diff [--json] [--breaking any|consumers|producers] <old.cue> <new.cue>
+//
+// Exit codes are stable so that the tool can be used within CI:
+//
+//	0  success
+//	1  an error occurred, eg. a file couldn't be read or compiled
+//	2  invalid usage, eg. unknown subcommands, flags or arguments
+//	3  validation failed;  the event doesn't satisfy its schema
+//	4  the new schema has breaking changes
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	ExitOK       = 0
+	ExitError    = 1
+	ExitUsage    = 2
+	ExitInvalid  = 3
+	ExitBreaking = 4
+)
+
+var (
+	// errUsage is returned when a command is given invalid flags or arguments.
+	errUsage = errors.New("invalid usage")
+	// errInvalid is returned when an event doesn't satisfy its schema.
+	errInvalid = errors.New("invalid event")
+	// errBreaking is returned when a schema has breaking changes.
+	errBreaking = errors.New("breaking changes")
+)
+
+// command is a single subcommand of the CLI.
+type command struct {
+	usage string
+	run   func(ctx context.Context, env env, args []string) error
+}
+
+// env stores the output streams for a command.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands = map[string]command{
+	"generate": {
+		usage: "generate --lang ts|jsonschema|cue [--dialect openapi|2020-12] <file.cue | event-name>",
+		run:   runGenerate,
+	},
+	"validate": {
+		usage: "validate <event.json>",
+		run:   runValidate,
+	},
+	"import": {
+		usage: "import [--formats] <schema.json>",
+		run:   runImport,
+	},
+	"infer": {
+		usage: "infer [--counts] [--formats] [--enums] <samples.jsonl>",
+		run:   runInfer,
+	},
+	"fake": {
+		usage: "fake <event-name> [-n 10] [--seed 1] [--now 2022-01-01T00:00:00Z]",
+		run:   runFake,
+	},
+	"merge": {
+		usage: "merge <a.cue> <b.cue> [...]",
+		run:   runMerge,
+	},
+	"diff": {
+		usage: "diff [--json] [--breaking any|consumers|producers] <old.cue> <new.cue>",
+		run:   runDiff,
+	},
+}
+
+func main() {
+	os.Exit(run(context.Background(), env{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}, os.Args[1:]))
+}
+
+// run runs the subcommand given within args, returning the exit code.
+func run(ctx context.Context, e env, args []string) int {
+	if len(args) == 0 {
+		usage(e.stderr)
+		return ExitUsage
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			usage(e.stdout)
+			return ExitOK
+		}
+		fmt.Fprintf(e.stderr, "unknown command: %s\n\n", args[0])
+		usage(e.stderr)
+		return ExitUsage
+	}
+
+	err := cmd.run(ctx, e, args[1:])
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(e.stderr, "%s\n\nusage: event-schemas %s\n", err, cmd.usage)
+		return ExitUsage
+	case errors.Is(err, errInvalid):
+		fmt.Fprintln(e.stderr, err)
+		return ExitInvalid
+	case errors.Is(err, errBreaking):
+		fmt.Fprintln(e.stderr, err)
+		return ExitBreaking
+	default:
+		fmt.Fprintln(e.stderr, err)
+		return ExitError
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  event-schemas %s\n", commands[name].usage)
+	}
+}
+
+// parseFlags parses flags which may be interspersed with positional
+// arguments, eg. "fake github/push -n 10", returning the positional
+// arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %s", errUsage, err)
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// usageErrorf returns an error wrapping errUsage.
+func usageErrorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// readInput reads the given file, or stdin if the file is "-".
+func readInput(e env, file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(e.stdin)
+	}
+	return os.ReadFile(file)
+}
+
+// isCueFile returns whether the argument refers to a cue file rather than a
+// registered event name.
+func isCueFile(arg string) bool {
+	return strings.HasSuffix(arg, ".cue") || arg == "-"
+}
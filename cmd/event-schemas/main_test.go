@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inngest/event-schemas/pkg/fakedata"
+	"github.com/stretchr/testify/require"
+)
+
+// exec runs the CLI with the given args, returning the exit code and output.
+func exec(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(context.Background(), env{
+		stdin:  &bytes.Buffer{},
+		stdout: stdout,
+		stderr: stderr,
+	}, args)
+	return code, stdout.String(), stderr.String()
+}
+
+func write(t *testing.T, name, contents string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(contents), 0600))
+	return file
+}
+
+func TestUsage(t *testing.T) {
+	code, _, stderr := exec(t)
+	require.Equal(t, ExitUsage, code)
+	require.Contains(t, stderr, "usage:")
+
+	code, _, _ = exec(t, "unknown")
+	require.Equal(t, ExitUsage, code)
+
+	code, _, _ = exec(t, "fake", "--unknown")
+	require.Equal(t, ExitUsage, code)
+
+	code, _, _ = exec(t, "generate", "--lang", "rust", "github/push")
+	require.Equal(t, ExitUsage, code)
+
+	code, stdout, _ := exec(t, "help")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "usage:")
+}
+
+func TestGenerate(t *testing.T) {
+	code, stdout, _ := exec(t, "generate", "--lang", "ts", "github/push")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "export interface InngestEvent")
+
+	code, stdout, _ = exec(t, "generate", "--lang", "jsonschema", "github/push")
+	require.Equal(t, ExitOK, code)
+	require.True(t, json.Valid([]byte(stdout)))
+
+	code, stdout, _ = exec(t, "generate", "--lang", "jsonschema", "--dialect", "2020-12", "github/push")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+	require.Contains(t, stdout, `"$id": "urn:inngest:event:github/push"`)
+	require.NotContains(t, stdout, `"nullable"`)
+
+	code, _, _ = exec(t, "generate", "--lang", "jsonschema", "--dialect", "draft-4", "github/push")
+	require.Equal(t, ExitUsage, code)
+
+	code, _, stderr := exec(t, "generate", "--lang", "cue", "github/unknown")
+	require.Equal(t, ExitError, code)
+	require.Contains(t, stderr, "unknown event")
+
+	file := write(t, "event.cue", "#Event: {\n\tname: string\n}\n")
+	code, stdout, _ = exec(t, "generate", file, "--lang", "ts")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "export interface Event")
+
+	// Each definition within a file is included in the schema document.
+	file = write(t, "event.cue", "#Event: {\n\tname: \"a/b\"\n\tdata: {\n\t\tid: string\n\t\tuser?: #User\n\t}\n}\n#User: {\n\temail: string\n}\n")
+	code, stdout, _ = exec(t, "generate", file, "--lang", "jsonschema")
+	require.Equal(t, ExitOK, code)
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	require.Contains(t, schemas, "Event")
+	require.Contains(t, schemas, "User")
+	require.Contains(t, stdout, `"$ref": "#/components/schemas/User"`)
+
+	code, stdout, _ = exec(t, "generate", file, "--lang", "jsonschema", "--dialect", "2020-12")
+	require.Equal(t, ExitOK, code)
+	doc = map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+	require.Equal(t, "https://json-schema.org/draft/2020-12/schema", doc["$schema"])
+	defs := doc["$defs"].(map[string]interface{})
+	event := defs["Event"].(map[string]interface{})
+	require.Equal(t, []interface{}{"name", "data"}, event["required"])
+	require.Contains(t, defs, "User")
+	require.Contains(t, stdout, `"$ref": "#/$defs/User"`)
+}
+
+func TestValidate(t *testing.T) {
+	unknown := write(t, "unknown.json", `{"name": "github/unknown"}`)
+	code, _, _ := exec(t, "validate", unknown)
+	require.Equal(t, ExitError, code)
+
+	invalid := write(t, "invalid.json", `{"name": "stripe/charge.failed", "data": {"object": 1}}`)
+	code, stdout, _ := exec(t, "validate", invalid)
+	require.Equal(t, ExitInvalid, code)
+	require.Contains(t, stdout, "$.data.object")
+}
+
+func TestFake(t *testing.T) {
+	code, stdout, _ := exec(t, "fake", "stripe/charge.failed", "-n", "3")
+	require.Equal(t, ExitOK, code)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		evt := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &evt))
+		require.Equal(t, "stripe/charge.failed", evt["name"])
+		require.Contains(t, evt, "ts")
+		require.Contains(t, evt, "user")
+	}
+
+	// Seeded output is reproducible, and each event differs.
+	_, first, _ := exec(t, "fake", "github/push", "-n", "2", "--seed", "5")
+	_, second, _ := exec(t, "fake", "github/push", "-n", "2", "--seed", "5")
+	require.Equal(t, first, second)
+	lines = strings.Split(strings.TrimSpace(first), "\n")
+	require.Len(t, lines, 2)
+	require.NotEqual(t, lines[0], lines[1])
+
+	// Seeded timestamps precede a fixed epoch, or --now.
+	evt := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &evt))
+	require.Less(t, evt["ts"], float64(fakedata.Epoch.UnixMilli()))
+
+	code, stdout, _ = exec(t, "fake", "github/push", "--seed", "5", "--now", "2030-01-01T00:00:00Z")
+	require.Equal(t, ExitOK, code)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &evt))
+	require.Greater(t, evt["ts"], float64(fakedata.Epoch.UnixMilli()))
+
+	code, _, _ = exec(t, "fake", "github/push", "--now", "yesterday")
+	require.Equal(t, ExitUsage, code)
+
+	// Custom definitions use their @fake attributes.
+	code, stdout, _ = exec(t, "fake", "shop/order.placed", "--defs", "../../events/parse/testdata/examples", "-n", "5")
+	require.Equal(t, ExitOK, code)
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		require.NoError(t, json.Unmarshal([]byte(line), &evt))
+		require.Contains(t, []interface{}{"web", "store"}, evt["data"].(map[string]interface{})["channel"])
+	}
+
+	// Fake events pass validation.
+	for _, name := range []string{"github/push", "github/pull_request", "github/delete", "stripe/charge.failed", "stripe/customer.created"} {
+		code, stdout, _ := exec(t, "fake", name, "--seed", "1")
+		require.Equal(t, ExitOK, code)
+		code, stdout, _ = exec(t, "validate", write(t, "event.json", stdout))
+		require.Equal(t, ExitOK, code, stdout)
+	}
+}
+
+func TestImport(t *testing.T) {
+	code, stdout, stderr := exec(t, "import", "../../events/marshalling/jsonschema/testdata/webhook/event.json")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "data:      #customer")
+	require.Contains(t, stderr, `warning: https://example.com/schemas/event.json#/properties/discount/if: unsupported keyword "if"`)
+	require.Contains(t, stdout, "id:        string")
+
+	code, stdout, _ = exec(t, "import", "--formats", "../../events/marshalling/jsonschema/testdata/webhook/event.json")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, `id:        =~"^[0-9a-fA-F]{8}`)
+
+	file := write(t, "event.json", `{"properties": {"customer": {"$ref": "customer.json"}}}`)
+	code, _, stderr = exec(t, "import", file)
+	require.Equal(t, ExitError, code)
+	require.Contains(t, stderr, "unable to resolve customer.json")
+}
+
+func TestInfer(t *testing.T) {
+	file := write(t, "samples.jsonl", "{\"id\": 1, \"name\": \"a\"}\n\n{\"id\": 2, \"ok\": true}\n")
+	code, stdout, _ := exec(t, "infer", file)
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "id:")
+	require.Contains(t, stdout, "name?: string")
+	require.Contains(t, stdout, "ok?:")
+
+	code, stdout, _ = exec(t, "infer", "--counts", file)
+	require.Equal(t, ExitOK, code)
+	require.Equal(t, "id\t2/2\nname\t1/2\nok\t1/2\n", stdout)
+
+	file = write(t, "status.jsonl", "{\"status\": \"a\"}\n{\"status\": \"b\"}\n{\"status\": \"a\"}\n{\"status\": \"b\"}\n")
+	code, stdout, _ = exec(t, "infer", "--enums", file)
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, `status: "a" | "b"`)
+
+	file = write(t, "invalid.jsonl", "{\"id\": ")
+	code, _, _ = exec(t, "infer", file)
+	require.Equal(t, ExitError, code)
+}
+
+func TestMerge(t *testing.T) {
+	a := write(t, "a.cue", "a: string\n")
+	b := write(t, "b.cue", "a: int\nb: bool\n")
+	code, stdout, _ := exec(t, "merge", a, b)
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "a:  string | int")
+	require.Contains(t, stdout, "b?: bool")
+
+	code, _, _ = exec(t, "merge", a)
+	require.Equal(t, ExitUsage, code)
+}
+
+func TestDiff(t *testing.T) {
+	old := write(t, "old.cue", "id: string\nemail?: string\n")
+	added := write(t, "added.cue", "id: string\nemail?: string\nname?: string\n")
+	removed := write(t, "removed.cue", "email?: string\n")
+
+	code, stdout, _ := exec(t, "diff", old, old)
+	require.Equal(t, ExitOK, code)
+	require.Empty(t, stdout)
+
+	code, stdout, _ = exec(t, "diff", old, added)
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "non-breaking: $.name: field added")
+
+	code, stdout, stderr := exec(t, "diff", old, removed)
+	require.Equal(t, ExitBreaking, code)
+	require.Contains(t, stdout, "breaking: $.id: field removed")
+	require.Contains(t, stderr, "1 breaking change(s)")
+
+	code, stdout, _ = exec(t, "diff", "--json", old, removed)
+	require.Equal(t, ExitBreaking, code)
+	report := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	require.Len(t, report["changes"], 1)
+
+	// Making a field optional only breaks consumers.
+	optional := write(t, "optional.cue", "id?: string\nemail?: string\n")
+	code, stdout, _ = exec(t, "diff", old, optional)
+	require.Equal(t, ExitBreaking, code)
+	require.Contains(t, stdout, "breaking: $.id: field optional")
+	code, _, _ = exec(t, "diff", "--breaking", "consumers", old, optional)
+	require.Equal(t, ExitBreaking, code)
+	code, stdout, _ = exec(t, "diff", "--breaking", "producers", old, optional)
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, stdout, "non-breaking: $.id: field optional")
+
+	code, _, _ = exec(t, "diff", "--breaking", "everyone", old, optional)
+	require.Equal(t, ExitUsage, code)
+	code, _, _ = exec(t, "diff", old)
+	require.Equal(t, ExitUsage, code)
+}
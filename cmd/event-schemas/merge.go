@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/pkg/cueutil"
+	"github.com/inngest/event-schemas/pkg/merge"
+)
+
+func runMerge(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		return usageErrorf("expected at least two cue files")
+	}
+
+	r := &cue.Runtime{}
+	var merged cue.Value
+	for _, file := range args {
+		byt, err := readInput(e, file)
+		if err != nil {
+			return err
+		}
+		inst, err := r.Compile(file, byt)
+		if err != nil {
+			return fmt.Errorf("error compiling %s: %w", file, err)
+		}
+		merged, err = merge.Merge(ctx, merged, inst.Value())
+		if err != nil {
+			return fmt.Errorf("error merging %s: %w", file, err)
+		}
+	}
+
+	str, err := cueutil.ASTToSyntax(merged.Syntax())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(e.stdout, str)
+	return err
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/inngest/event-schemas/events"
+)
+
+func runValidate(ctx context.Context, e env, args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	defs := fs.String("defs", "", "a directory of custom event definitions")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageErrorf("expected an event JSON file")
+	}
+
+	byt, err := readInput(e, args[0])
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(byt, &payload); err != nil {
+		return fmt.Errorf("error parsing %s: %w", args[0], err)
+	}
+	name, _ := payload["name"].(string)
+	if name == "" {
+		return fmt.Errorf("%w: the event has no name", errInvalid)
+	}
+
+	r, err := registry(ctx, *defs)
+	if err != nil {
+		return err
+	}
+
+	err = r.Validate(name, payload)
+	verrs := events.ValidationErrors{}
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	for _, v := range verrs {
+		actual, _ := json.Marshal(v.Actual)
+		fmt.Fprintf(e.stdout, "%s: %s (expected %s, got %s)\n", v.Path, v.Message, v.Expected, actual)
+	}
+	return fmt.Errorf("%w: %s has %d error(s)", errInvalid, name, len(verrs))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/inngest/cuetypescript"
 	"github.com/inngest/event-schemas/events/marshalling/fromjson"
 	"github.com/inngest/event-schemas/events/marshalling/jsonschema"
+	"github.com/inngest/event-schemas/events/marshalling/zod"
 	"github.com/inngest/event-schemas/pkg/cueutil"
 	"github.com/inngest/event-schemas/pkg/merge"
 )
@@ -21,6 +22,7 @@ func main() {
 	// Expose the "parseCue" function
 	js.Global().Set("fromJSON", js.FuncOf(FromJSON))
 	js.Global().Set("toTS", js.FuncOf(ToTS))
+	js.Global().Set("toZod", js.FuncOf(ToZod))
 	js.Global().Set("toJSONSchema", js.FuncOf(ToJSONSchema))
 	js.Global().Set("merge", js.FuncOf(Merge))
 
@@ -28,20 +30,23 @@ func main() {
 	<-make(chan struct{})
 }
 
+// FromJSON generates a cue type from a JSON string.  Fields retain the order of
+// keys within the JSON, unless the optional second argument is true, in which
+// case fields are sorted alphabetically.
 func FromJSON(this js.Value, args []js.Value) interface{} {
-	if len(args) != 1 {
+	if len(args) < 1 {
 		return fmt.Sprintf("error: no JSON string provided")
 	}
 
-	input := args[0].String()
-	mapped := map[string]interface{}{}
-	if err := json.Unmarshal([]byte(input), &mapped); err != nil {
-		return fmt.Sprintf("error parsing JSON string: %w", err)
+	opts := fromjson.Options{}
+	if len(args) > 1 {
+		opts.SortKeys = args[1].Truthy()
 	}
 
-	cue, err := fromjson.FromJSON(mapped)
+	input := args[0].String()
+	cue, err := fromjson.FromJSONBytes([]byte(input), opts)
 	if err != nil {
-		return fmt.Sprintf("error generating CUE type: %w", err)
+		return fmt.Sprintf("error generating CUE type: %s", err)
 	}
 
 	return cue
@@ -92,6 +97,20 @@ func ToTS(this js.Value, args []js.Value) interface{} {
 	return ts
 }
 
+func ToZod(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return fmt.Sprintf("error: no cue type provided")
+	}
+
+	input := args[0].String()
+	schema, err := zod.MarshalString(input)
+	if err != nil {
+		return fmt.Sprintf("error generating zod schema: %s", err)
+	}
+
+	return schema
+}
+
 func ToJSONSchema(this js.Value, args []js.Value) interface{} {
 	if len(args) != 1 {
 		return fmt.Sprintf("error: no cue type provided")
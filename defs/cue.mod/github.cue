@@ -1,5 +1,28 @@
 package eventdefintions
 
+// GitHubUser is a GitHub user or organization, as included within the sender,
+// owner and user fields of GitHub webhooks.
+#GitHubUser: {
+	node_id:             string
+	html_url:            string
+	repos_url:           string
+	type:                string
+	id:                  int
+	avatar_url:          string
+	gravatar_id:         string
+	following_url:       string
+	gists_url:           string
+	site_admin:          bool
+	login:               string
+	url:                 string
+	followers_url:       string
+	starred_url:         string
+	subscriptions_url:   string
+	organizations_url:   string
+	received_events_url: string
+	events_url:          string
+}
+
 comment: #Def & {
 	description: "Created when comments are created or modified"
 	schema: {
@@ -21,47 +44,9 @@ comment: #Def & {
 				public_members_url: string
 				avatar_url:         string
 			}
-			sender: {
-				node_id:             string
-				html_url:            string
-				repos_url:           string
-				type:                string
-				id:                  int
-				avatar_url:          string
-				gravatar_id:         string
-				following_url:       string
-				gists_url:           string
-				site_admin:          bool
-				login:               string
-				url:                 string
-				followers_url:       string
-				starred_url:         string
-				subscriptions_url:   string
-				organizations_url:   string
-				received_events_url: string
-				events_url:          string
-			}
+			sender: #GitHubUser
 			issue: {
-				user: {
-					gists_url:           string
-					repos_url:           string
-					received_events_url: string
-					site_admin:          bool
-					login:               string
-					url:                 string
-					events_url:          string
-					followers_url:       string
-					starred_url:         string
-					type:                string
-					avatar_url:          string
-					subscriptions_url:   string
-					gravatar_id:         string
-					html_url:            string
-					following_url:       string
-					organizations_url:   string
-					id:                  int
-					node_id:             string
-				}
+				user: #GitHubUser
 				updated_at:         string
 				comments_url:       string
 				draft:              bool
@@ -111,26 +96,7 @@ comment: #Def & {
 			comment: {
 				issue_url: string
 				id:        int
-				user: {
-					html_url:            string
-					events_url:          string
-					received_events_url: string
-					node_id:             string
-					gravatar_id:         string
-					repos_url:           string
-					type:                string
-					avatar_url:          string
-					gists_url:           string
-					url:                 string
-					organizations_url:   string
-					site_admin:          bool
-					login:               string
-					id:                  int
-					starred_url:         string
-					subscriptions_url:   string
-					followers_url:       string
-					following_url:       string
-				}
+				user: #GitHubUser
 				created_at:         string
 				updated_at:         string
 				author_association: string
@@ -201,28 +167,9 @@ comment: #Def & {
 				has_projects:      bool
 				open_issues_count: int
 				disabled:          bool
-				default_branch:    string
+				default_branch:    string @fake(enum="main|master")
 				name:              string
-				owner: {
-					following_url:       string
-					organizations_url:   string
-					received_events_url: string
-					type:                string
-					login:               string
-					followers_url:       string
-					gists_url:           string
-					starred_url:         string
-					repos_url:           string
-					id:                  int
-					url:                 string
-					subscriptions_url:   string
-					site_admin:          bool
-					node_id:             string
-					avatar_url:          string
-					gravatar_id:         string
-					html_url:            string
-					events_url:          string
-				}
+				owner: #GitHubUser
 				description:     _
 				trees_url:       string
 				contents_url:    string
@@ -292,30 +239,11 @@ pull_request: #Def & {
 				commits_url:  string
 				merged_at:    _
 				statuses_url: string
-				user: {
-					events_url:          string
-					node_id:             string
-					organizations_url:   string
-					type:                string
-					url:                 string
-					following_url:       string
-					gists_url:           string
-					html_url:            string
-					repos_url:           string
-					followers_url:       string
-					id:                  int
-					site_admin:          bool
-					starred_url:         string
-					subscriptions_url:   string
-					avatar_url:          string
-					gravatar_id:         string
-					login:               string
-					received_events_url: string
-				}
+				user: #GitHubUser
 				author_association: string
 				base: {
 					label: string
-					ref:   string
+					ref:   string @fake(enum="main|develop")
 					repo: {
 						branches_url:    string
 						name:            string
@@ -357,7 +285,7 @@ pull_request: #Def & {
 						statuses_url:           string
 						allow_forking:          bool
 						contributors_url:       string
-						default_branch:         string
+						default_branch:         string @fake(enum="main|master")
 						fork:                   bool
 						forks_url:              string
 						git_refs_url:           string
@@ -390,26 +318,7 @@ pull_request: #Def & {
 						has_projects:           bool
 						id:                     int
 						pulls_url:              string
-						owner: {
-							node_id:             string
-							organizations_url:   string
-							repos_url:           string
-							events_url:          string
-							html_url:            string
-							login:               string
-							avatar_url:          string
-							type:                string
-							subscriptions_url:   string
-							following_url:       string
-							id:                  int
-							received_events_url: string
-							site_admin:          bool
-							starred_url:         string
-							url:                 string
-							followers_url:       string
-							gists_url:           string
-							gravatar_id:         string
-						}
+						owner: #GitHubUser
 						comments_url:    string
 						description:     string
 						homepage:        _
@@ -420,32 +329,13 @@ pull_request: #Def & {
 						node_id:         string
 						url:             string
 					}
-					sha: string
-					user: {
-						events_url:          string
-						followers_url:       string
-						following_url:       string
-						gravatar_id:         string
-						starred_url:         string
-						subscriptions_url:   string
-						site_admin:          bool
-						type:                string
-						node_id:             string
-						organizations_url:   string
-						repos_url:           string
-						avatar_url:          string
-						gists_url:           string
-						html_url:            string
-						id:                  int
-						login:               string
-						received_events_url: string
-						url:                 string
-					}
+					sha: string @fake(regex="^[0-9a-f]{40}$")
+					user: #GitHubUser
 				}
 				// The commit hash of the tip of the PR before changes
-				before?: string
+				before?: string @fake(regex="^[0-9a-f]{40}$")
 				// The commit hash of the tip of the PR after changes
-				after?: string
+				after?: string @fake(regex="^[0-9a-f]{40}$")
 				// The number of changed files
 				changed_files: int & >=1
 				milestone:     _
@@ -464,7 +354,7 @@ pull_request: #Def & {
 				created_at:         string
 				head: {
 					label: string
-					ref:   string
+					ref:   string @fake(enum="feature/signup|fix/login-redirect|update-deps")
 					repo: {
 						pulls_url:         string
 						releases_url:      string
@@ -497,28 +387,9 @@ pull_request: #Def & {
 						allow_forking:     bool
 						commits_url:       string
 						contents_url:      string
-						default_branch:    string
+						default_branch:    string @fake(enum="main|master")
 						forks:             int
-						owner: {
-							starred_url:         string
-							subscriptions_url:   string
-							type:                string
-							node_id:             string
-							site_admin:          bool
-							organizations_url:   string
-							repos_url:           string
-							gists_url:           string
-							id:                  int
-							events_url:          string
-							login:               string
-							following_url:       string
-							gravatar_id:         string
-							html_url:            string
-							received_events_url: string
-							url:                 string
-							avatar_url:          string
-							followers_url:       string
-						}
+						owner: #GitHubUser
 						allow_merge_commit:     bool
 						archived:               bool
 						forks_url:              string
@@ -569,27 +440,8 @@ pull_request: #Def & {
 						labels_url:             string
 						node_id:                string
 					}
-					sha: string
-					user: {
-						node_id:             string
-						organizations_url:   string
-						received_events_url: string
-						url:                 string
-						id:                  int
-						repos_url:           string
-						login:               string
-						subscriptions_url:   string
-						type:                string
-						avatar_url:          string
-						events_url:          string
-						gravatar_id:         string
-						html_url:            string
-						starred_url:         string
-						followers_url:       string
-						following_url:       string
-						gists_url:           string
-						site_admin:          bool
-					}
+					sha: string @fake(regex="^[0-9a-f]{40}$")
+					user: #GitHubUser
 				}
 				requested_reviewers: [...]
 				assignee:            _
@@ -672,32 +524,13 @@ pull_request: #Def & {
 				contents_url:      string
 				deployments_url:   string
 				notifications_url: string
-				owner: {
-					login:               string
-					node_id:             string
-					repos_url:           string
-					site_admin:          bool
-					url:                 string
-					followers_url:       string
-					gravatar_id:         string
-					html_url:            string
-					id:                  int
-					received_events_url: string
-					starred_url:         string
-					events_url:          string
-					type:                string
-					avatar_url:          string
-					following_url:       string
-					gists_url:           string
-					organizations_url:   string
-					subscriptions_url:   string
-				}
+				owner: #GitHubUser
 				releases_url:      string
 				stargazers_count:  int
 				blobs_url:         string
 				issue_events_url:  string
 				tags_url:          string
-				default_branch:    string
+				default_branch:    string @fake(enum="main|master")
 				events_url:        string
 				hooks_url:         string
 				statuses_url:      string
@@ -737,26 +570,7 @@ pull_request: #Def & {
 				url:               string
 				watchers_count:    int
 			}
-			sender: {
-				events_url:          string
-				gists_url:           string
-				login:               string
-				url:                 string
-				followers_url:       string
-				following_url:       string
-				id:                  int
-				site_admin:          bool
-				subscriptions_url:   string
-				type:                string
-				html_url:            string
-				node_id:             string
-				avatar_url:          string
-				gravatar_id:         string
-				organizations_url:   string
-				received_events_url: string
-				repos_url:           string
-				starred_url:         string
-			}
+			sender: #GitHubUser
 		}
 
 		// There is no user information available within this event.
@@ -769,13 +583,13 @@ push: #Def & {
 	schema: {
 		name: "github/push"
 		data: {
-			before:      string
+			before:      string @fake(regex="^[0-9a-f]{40}$")
 			deleted:     bool
 			base_ref:    _
 			forced:      bool
 			compare:     string
 			head_commit: _
-			ref:         string
+			ref:         string @fake(enum="refs/heads/main|refs/heads/develop|refs/heads/feature/signup")
 			repository: {
 				git_commits_url:   string
 				labels_url:        string
@@ -810,11 +624,11 @@ push: #Def & {
 				svn_url:           string
 				stargazers_count:  int
 				allow_forking:     bool
-				master_branch:     string
+				master_branch:     string @fake(enum="main|master")
 				description:       _
 				teams_url:         string
 				notifications_url: string
-				default_branch:    string
+				default_branch:    string @fake(enum="main|master")
 				hooks_url:         string
 				comments_url:      string
 				issue_comment_url: string
@@ -880,7 +694,7 @@ push: #Def & {
 				issue_events_url: string
 			}
 			created: bool
-			after:   string
+			after:   string @fake(regex="^[0-9a-f]{40}$")
 			pusher: {
 				name:  string
 				email: string
@@ -899,26 +713,7 @@ push: #Def & {
 				url:                string
 				members_url:        string
 			}
-			sender: {
-				html_url:            string
-				followers_url:       string
-				starred_url:         string
-				type:                string
-				id:                  int
-				avatar_url:          string
-				url:                 string
-				site_admin:          bool
-				following_url:       string
-				subscriptions_url:   string
-				repos_url:           string
-				events_url:          string
-				login:               string
-				gravatar_id:         string
-				gists_url:           string
-				node_id:             string
-				organizations_url:   string
-				received_events_url: string
-			}
+			sender: #GitHubUser
 			commits: [...]
 		}
 	}
@@ -972,7 +767,7 @@ delete: #Def & {
 				git_commits_url:   string
 				archive_url:       string
 				milestones_url:    string
-				default_branch:    string
+				default_branch:    string @fake(enum="main|master")
 				full_name:         string
 				fork:              bool
 				url:               string
@@ -980,26 +775,7 @@ delete: #Def & {
 				subscription_url:  string
 				visibility:        string
 				id:                int
-				owner: {
-					html_url:            string
-					subscriptions_url:   string
-					events_url:          string
-					followers_url:       string
-					gists_url:           string
-					node_id:             string
-					url:                 string
-					starred_url:         string
-					organizations_url:   string
-					repos_url:           string
-					received_events_url: string
-					login:               string
-					id:                  int
-					type:                string
-					site_admin:          bool
-					following_url:       string
-					avatar_url:          string
-					gravatar_id:         string
-				}
+				owner: #GitHubUser
 				forks_count:       int
 				license:           _
 				assignees_url:     string
@@ -1042,27 +818,8 @@ delete: #Def & {
 				members_url:        string
 				description:        string
 			}
-			sender: {
-				avatar_url:          string
-				url:                 string
-				received_events_url: string
-				type:                string
-				site_admin:          bool
-				login:               string
-				node_id:             string
-				repos_url:           string
-				events_url:          string
-				gravatar_id:         string
-				followers_url:       string
-				following_url:       string
-				subscriptions_url:   string
-				organizations_url:   string
-				id:                  int
-				html_url:            string
-				gists_url:           string
-				starred_url:         string
-			}
-			ref:      string
+			sender: #GitHubUser
+			ref:      string @fake(enum="feature/signup|fix/login-redirect|update-deps")
 			ref_type: string
 		}
 	}
@@ -1075,9 +832,9 @@ check_suite: #Def & {
 		data: {
 			check_suite: {
 				conclusion:         string
-				before:             string
+				before:             string @fake(regex="^[0-9a-f]{40}$")
 				runs_rerequestable: bool
-				head_sha:           string
+				head_sha:           string @fake(regex="^[0-9a-f]{40}$")
 				status:             string
 				pull_requests: [...]
 				updated_at: string
@@ -1101,26 +858,7 @@ check_suite: #Def & {
 					events: [...string]
 					slug:    string
 					node_id: string
-					owner: {
-						node_id:             string
-						avatar_url:          string
-						gists_url:           string
-						events_url:          string
-						url:                 string
-						starred_url:         string
-						subscriptions_url:   string
-						received_events_url: string
-						site_admin:          bool
-						id:                  int
-						html_url:            string
-						followers_url:       string
-						organizations_url:   string
-						type:                string
-						login:               string
-						gravatar_id:         string
-						following_url:       string
-						repos_url:           string
-					}
+					owner: #GitHubUser
 					external_url: string
 					created_at:   string
 					permissions: {
@@ -1152,8 +890,8 @@ check_suite: #Def & {
 				latest_check_runs_count: int
 				check_runs_url:          string
 				id:                      int
-				after:                   string
-				head_branch:             string
+				after:                   string @fake(regex="^[0-9a-f]{40}$")
+				head_branch:             string @fake(enum="feature/signup|fix/login-redirect|update-deps")
 				created_at:              string
 			}
 			repository: {
@@ -1161,7 +899,7 @@ check_suite: #Def & {
 				name:              string
 				has_wiki:          bool
 				allow_forking:     bool
-				default_branch:    string
+				default_branch:    string @fake(enum="main|master")
 				statuses_url:      string
 				comments_url:      string
 				pulls_url:         string
@@ -1203,26 +941,7 @@ check_suite: #Def & {
 				git_url:           string
 				mirror_url:        _
 				forks:             int
-				owner: {
-					site_admin:          bool
-					gists_url:           string
-					starred_url:         string
-					organizations_url:   string
-					repos_url:           string
-					login:               string
-					html_url:            string
-					followers_url:       string
-					following_url:       string
-					type:                string
-					url:                 string
-					subscriptions_url:   string
-					events_url:          string
-					received_events_url: string
-					id:                  int
-					node_id:             string
-					avatar_url:          string
-					gravatar_id:         string
-				}
+				owner: #GitHubUser
 				assignees_url:     string
 				branches_url:      string
 				pushed_at:         string
@@ -1268,26 +987,7 @@ check_suite: #Def & {
 				node_id:            string
 				url:                string
 			}
-			sender: {
-				id:                  int
-				following_url:       string
-				gists_url:           string
-				type:                string
-				site_admin:          bool
-				login:               string
-				url:                 string
-				organizations_url:   string
-				repos_url:           string
-				events_url:          string
-				avatar_url:          string
-				gravatar_id:         string
-				html_url:            string
-				subscriptions_url:   string
-				node_id:             string
-				followers_url:       string
-				starred_url:         string
-				received_events_url: string
-			}
+			sender: #GitHubUser
 			action: string
 		}
 	}
@@ -1353,26 +1053,7 @@ workflow_job: #Def & {
 				contents_url:      string
 				deployments_url:   string
 				private:           bool
-				owner: {
-					id:                  int
-					avatar_url:          string
-					following_url:       string
-					organizations_url:   string
-					type:                string
-					node_id:             string
-					gravatar_id:         string
-					url:                 string
-					html_url:            string
-					starred_url:         string
-					repos_url:           string
-					followers_url:       string
-					subscriptions_url:   string
-					events_url:          string
-					received_events_url: string
-					login:               string
-					gists_url:           string
-					site_admin:          bool
-				}
+				owner: #GitHubUser
 				html_url:          string
 				archived:          bool
 				license:           _
@@ -1436,26 +1117,7 @@ workflow_job: #Def & {
 				issues_url:         string
 				avatar_url:         string
 			}
-			sender: {
-				login:               string
-				subscriptions_url:   string
-				organizations_url:   string
-				url:                 string
-				gists_url:           string
-				repos_url:           string
-				type:                string
-				site_admin:          bool
-				id:                  int
-				node_id:             string
-				avatar_url:          string
-				html_url:            string
-				starred_url:         string
-				received_events_url: string
-				gravatar_id:         string
-				followers_url:       string
-				following_url:       string
-				events_url:          string
-			}
+			sender: #GitHubUser
 		}
 	}
 }
@@ -1529,26 +1191,7 @@ workflow_run: #Def & {
 					contents_url:      string
 					merges_url:        string
 					issues_url:        string
-					owner: {
-						gists_url:           string
-						starred_url:         string
-						type:                string
-						node_id:             string
-						avatar_url:          string
-						url:                 string
-						html_url:            string
-						login:               string
-						site_admin:          bool
-						repos_url:           string
-						events_url:          string
-						gravatar_id:         string
-						followers_url:       string
-						following_url:       string
-						organizations_url:   string
-						id:                  int
-						subscriptions_url:   string
-						received_events_url: string
-					}
+					owner: #GitHubUser
 					trees_url:        string
 					statuses_url:     string
 					comments_url:     string
@@ -1582,26 +1225,7 @@ workflow_run: #Def & {
 					commits_url:      string
 					compare_url:      string
 					merges_url:       string
-					owner: {
-						login:               string
-						avatar_url:          string
-						following_url:       string
-						organizations_url:   string
-						repos_url:           string
-						received_events_url: string
-						site_admin:          bool
-						id:                  int
-						gravatar_id:         string
-						starred_url:         string
-						node_id:             string
-						gists_url:           string
-						subscriptions_url:   string
-						type:                string
-						url:                 string
-						html_url:            string
-						followers_url:       string
-						events_url:          string
-					}
+					owner: #GitHubUser
 					description:       _
 					collaborators_url: string
 					stargazers_url:    string
@@ -1716,26 +1340,7 @@ workflow_run: #Def & {
 				forks:             int
 				open_issues:       int
 				language:          string
-				owner: {
-					site_admin:          bool
-					gravatar_id:         string
-					repos_url:           string
-					type:                string
-					followers_url:       string
-					starred_url:         string
-					received_events_url: string
-					avatar_url:          string
-					url:                 string
-					html_url:            string
-					id:                  int
-					gists_url:           string
-					subscriptions_url:   string
-					organizations_url:   string
-					events_url:          string
-					login:               string
-					node_id:             string
-					following_url:       string
-				}
+				owner: #GitHubUser
 				git_tags_url: string
 				trees_url:    string
 				statuses_url: string
@@ -1758,26 +1363,7 @@ workflow_run: #Def & {
 				hooks_url:          string
 				issues_url:         string
 			}
-			sender: {
-				url:                 string
-				html_url:            string
-				followers_url:       string
-				events_url:          string
-				site_admin:          bool
-				starred_url:         string
-				subscriptions_url:   string
-				organizations_url:   string
-				type:                string
-				gravatar_id:         string
-				gists_url:           string
-				received_events_url: string
-				login:               string
-				id:                  int
-				node_id:             string
-				avatar_url:          string
-				following_url:       string
-				repos_url:           string
-			}
+			sender: #GitHubUser
 			workflow: {
 				html_url:   string
 				node_id:    string
@@ -10,22 +10,22 @@ stripe_customer_created: #Def & {
 			id: string
 			data: {
 				object: {
-					default_source?: string
+					default_source?: string | null
 					delinquent:      bool
 					invoice_prefix:  string
 					invoice_settings: {
-						custom_fields?: [...{name: string, value: string}]
-						default_payment_method?: string
-						footer?:                 string
+						custom_fields?:          [...{name: string, value: string}] | null
+						default_payment_method?: string | null
+						footer?:                 string | null
 					}
 					livemode: bool
 					metadata: {}
 					preferred_locales: [...string]
 					id:        string
-					name?:     string
+					name?:     string | null
 					shipping:  _
 					balance:   int
-					currency?: string
+					currency?: string | null
 					created:   int
 					address?: {
 						city:        string | null
@@ -34,17 +34,17 @@ stripe_customer_created: #Def & {
 						line2:       string | null
 						postal_code: string | null
 						state:       string | null
-					}
+					} | null
 					description: string
 					discount?: {
 						id:    string
 						start: int
 						end:   int
 						...
-					}
-					email?:                string
+					} | null
+					email?:                string | null
 					next_invoice_sequence: int
-					phone?:                string
+					phone?:                string | null
 					tax_exempt:            string
 					object:                string
 				}
@@ -2,6 +2,8 @@
 
 package events
 
+import "github.com/inngest/event-schemas/events/marshalling/jsonschema"
+
 // Event represents a single event payload.  It is generated by parsing the
 // cue definitions for each event within this repo.
 type Event struct {
@@ -19,15 +21,44 @@ type Event struct {
 	// event schemas over time.
 	Version string `json:"version"`
 
-	// Cue is the cue type definition of the event, without annotations.
+	// Latest is true if this is the newest version of the event.
+	Latest bool `json:"latest"`
+
+	// Cue is the cue type definition of the event, including attributes such
+	// as @fake.
 	Cue string `json:"cue"`
 
-	// Schema is the JSON schema definition of the event.
+	// Schema is the JSON schema definition of the event, as an OpenAPI 3.0
+	// schema object.  Use JSONSchema for a standard JSON schema document.
 	Schema map[string]interface{} `json:"schema"`
 
 	// TypeScript is the TypeScript definition of the event.
 	TypeScript string `json:"typescript"`
 
+	// GoType is the Go type definition of the event.
+	GoType string `json:"go"`
+
+	// Python is the Python definition of the event, using TypedDicts.
+	Python string `json:"python"`
+
+	// Zod is the zod schema of the event, along with its inferred
+	// TypeScript type.
+	Zod string `json:"zod"`
+
 	// Example are canonical example events to display in the UI.
 	Examples []map[string]interface{} `json:"examples,omitempty"`
+
+	// ExamplesGenerated is true if the examples are fake data generated from
+	// the schema, rather than captured from the service, because the
+	// definition doesn't provide any examples.
+	ExamplesGenerated bool `json:"examplesGenerated,omitempty"`
+}
+
+// JSONSchema returns a standalone JSON schema draft 2020-12 document for the
+// event, with an "$id" derived from the event's name and version.
+func (e Event) JSONSchema() map[string]interface{} {
+	return jsonschema.Convert(e.Schema, jsonschema.Options{
+		Dialect: jsonschema.Dialect202012,
+		ID:      jsonschema.EventID(e.Name, e.Version),
+	})
 }
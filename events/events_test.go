@@ -0,0 +1,25 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventJSONSchema(t *testing.T) {
+	for _, evt := range Events {
+		schema := evt.JSONSchema()
+		require.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"], evt.Name)
+		require.NotEmpty(t, schema["$id"], evt.Name)
+
+		// OpenAPI keywords must be rewritten.
+		byt, err := json.Marshal(schema)
+		require.NoError(t, err)
+		require.NotContains(t, string(byt), `"nullable"`, evt.Name)
+		require.NotContains(t, string(byt), "#/components/schemas/", evt.Name)
+
+		// The OpenAPI schema is unchanged.
+		require.NotContains(t, evt.Schema, "$schema", evt.Name)
+	}
+}
@@ -7,8 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 
-	"github.com/inngest/event-schemas/events/internal/parse"
+	"github.com/inngest/event-schemas/events"
+	"github.com/inngest/event-schemas/events/marshalling/asyncapi"
+	"github.com/inngest/event-schemas/events/marshalling/openapi"
+	"github.com/inngest/event-schemas/events/parse"
 )
 
 func main() {
@@ -20,14 +25,20 @@ func main() {
 
 func generateJSON() error {
 	ctx := context.Background()
-	events, err := parse.Parse(ctx)
+	evts, err := parse.ParseDefs(ctx)
 	if err != nil {
 		return err
 	}
 
+	// The embedded registry is created when the package is initialized, so
+	// check that the events can be indexed before embedding them.
+	if _, err := events.NewRegistry(evts); err != nil {
+		return err
+	}
+
 	// XXX: We can use a fast marshaller here, such as fastjson, as
 	// we know the event shape already.
-	byt, err := json.MarshalIndent(events, "", "  ")
+	byt, err := json.MarshalIndent(evts, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -37,5 +48,71 @@ func generateJSON() error {
 		return err
 	}
 
+	// Embed the same JSON within the events package, so that events.Events
+	// always matches generated.json.
+	embed := fmt.Sprintf(embedTemplate, strconv.Quote(string(byt)))
+	if err := os.WriteFile("embed.go", []byte(embed), 0600); err != nil {
+		return err
+	}
+
+	// Document the whole catalog as an AsyncAPI document.
+	doc, err := asyncapi.Generate(evts, asyncapi.Options{
+		Description: "Events ingested via integrations into Inngest.",
+	})
+	if err != nil {
+		return err
+	}
+	yml, err := doc.YAML()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile("asyncapi.yaml", yml, 0600); err != nil {
+		return err
+	}
+
+	// Document each service's webhooks as an OpenAPI document, written to
+	// events/openapi/<service>.yaml.
+	docs, err := openapi.GenerateServices(evts, openapi.Options{})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll("openapi", 0755); err != nil {
+		return err
+	}
+	for service, doc := range docs {
+		yml, err := doc.YAML()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join("openapi", service+".yaml"), yml, 0600); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+const embedTemplate = `// Code generated by generate.go; DO NOT EDIT.
+
+package events
+
+import (
+	"encoding/json"
+)
+
+// Events list a subset of events ingested via integrations into Inngest,
+// with cue and JSON schema fields documenting their format.
+var Events []Event
+
+// DefaultRegistry indexes Events by name, service and version.
+var DefaultRegistry *Registry
+
+func init() {
+	_ = json.Unmarshal([]byte(encoded), &Events)
+	// Duplicate versions are rejected when generating, so this never panics.
+	DefaultRegistry = MustNewRegistry(Events)
+}
+
+// encoded stores the JSON encoded event struct with pre-parsed cue and JSON
+// schema definitions.
+const encoded = %s
+`
@@ -0,0 +1,254 @@
+// Package asyncapi generates AsyncAPI documents describing a catalog of
+// events:  one channel per service, and one message per event.
+package asyncapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/inngest/event-schemas/events"
+	"github.com/inngest/event-schemas/events/marshalling/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Version26 = "2.6.0"
+	Version30 = "3.0.0"
+
+	contentType = "application/json"
+
+	// schemaFormat is the format of each message's payload.  AsyncAPI 2.6
+	// and 3.0 support JSON schema draft-07, but not later drafts.
+	schemaFormat = "application/schema+json;version=draft-07"
+
+	// defaultService is the channel for events without a service.
+	defaultService = "events"
+
+	// generatedSummary summarizes examples generated from the schema.
+	generatedSummary = "Generated from the schema with fake data"
+)
+
+// invalidKey matches characters which aren't valid within component keys.
+var invalidKey = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Options configures the generated document.
+type Options struct {
+	// Version is the AsyncAPI version of the document:  Version26 or
+	// Version30.  This defaults to Version26.
+	Version string
+	// Title is the title of the API.  This defaults to "Inngest events".
+	Title string
+	// APIVersion is the version of the API.  This defaults to "1.0.0".
+	APIVersion string
+	// Description is the description of the API.
+	Description string
+}
+
+// Document is an AsyncAPI document.
+type Document struct {
+	AsyncAPI           string                 `json:"asyncapi" yaml:"asyncapi"`
+	Info               Info                   `json:"info" yaml:"info"`
+	DefaultContentType string                 `json:"defaultContentType" yaml:"defaultContentType"`
+	Channels           map[string]interface{} `json:"channels" yaml:"channels"`
+	Operations         map[string]interface{} `json:"operations,omitempty" yaml:"operations,omitempty"`
+	Components         Components             `json:"components" yaml:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Components stores every message, which channels reference.
+type Components struct {
+	Messages map[string]interface{} `json:"messages" yaml:"messages"`
+}
+
+// JSON returns the document as indented JSON.
+func (d Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the document as YAML.
+func (d Document) YAML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Generate generates an AsyncAPI document for the given events.  Each service
+// becomes a channel, eg. "stripe", which receives a message for each of the
+// service's events.  Messages contain the event's JSON schema draft-07 as the
+// payload, along with the event's description and examples.
+func Generate(evts []events.Event, o Options) (Document, error) {
+	if o.Version == "" {
+		o.Version = Version26
+	}
+	if o.Version != Version26 && o.Version != Version30 {
+		return Document{}, fmt.Errorf("unsupported AsyncAPI version: %s", o.Version)
+	}
+	if o.Title == "" {
+		o.Title = "Inngest events"
+	}
+	if o.APIVersion == "" {
+		o.APIVersion = "1.0.0"
+	}
+
+	doc := Document{
+		AsyncAPI: o.Version,
+		Info: Info{
+			Title:       o.Title,
+			Version:     o.APIVersion,
+			Description: o.Description,
+		},
+		DefaultContentType: contentType,
+		Channels:           map[string]interface{}{},
+		Components: Components{
+			Messages: map[string]interface{}{},
+		},
+	}
+
+	// Group message references by service, retaining the order of events.
+	services := []string{}
+	refs := map[string][]string{}
+	for _, evt := range evts {
+		key := MessageKey(evt)
+		if _, ok := doc.Components.Messages[key]; ok {
+			return Document{}, fmt.Errorf("duplicate message for %s", key)
+		}
+		doc.Components.Messages[key] = message(evt, o.Version)
+
+		service := evt.Service
+		if service == "" {
+			service = defaultService
+		}
+		if _, ok := refs[service]; !ok {
+			services = append(services, service)
+		}
+		refs[service] = append(refs[service], key)
+	}
+
+	if o.Version == Version30 {
+		doc.Operations = map[string]interface{}{}
+	}
+	for _, service := range services {
+		addChannel(&doc, service, refs[service])
+	}
+	return doc, nil
+}
+
+// MessageKey returns the key of the event's message within the document's
+// components, eg. "stripe.charge.failed", or "acme.signup.v2" for a versioned
+// event.
+func MessageKey(evt events.Event) string {
+	key := strings.ReplaceAll(evt.Name, "/", ".")
+	if evt.Version != "" {
+		key += ".v" + evt.Version
+	}
+	return invalidKey.ReplaceAllString(key, "_")
+}
+
+func addChannel(doc *Document, service string, keys []string) {
+	description := fmt.Sprintf("Events sent by %s.", service)
+
+	if doc.AsyncAPI == Version26 {
+		messages := make([]interface{}, len(keys))
+		for n, key := range keys {
+			messages[n] = ref("#/components/messages/" + key)
+		}
+		doc.Channels[service] = map[string]interface{}{
+			"description": description,
+			"subscribe": map[string]interface{}{
+				"operationId": operationID(service),
+				"message":     map[string]interface{}{"oneOf": messages},
+			},
+		}
+		return
+	}
+
+	// AsyncAPI 3.0 lists messages within channels, and operations reference
+	// the channel's messages.
+	channelMessages := map[string]interface{}{}
+	operationMessages := make([]interface{}, len(keys))
+	for n, key := range keys {
+		channelMessages[key] = ref("#/components/messages/" + key)
+		operationMessages[n] = ref("#/channels/" + service + "/messages/" + key)
+	}
+	doc.Channels[service] = map[string]interface{}{
+		"address":     service,
+		"description": description,
+		"messages":    channelMessages,
+	}
+	doc.Operations[operationID(service)] = map[string]interface{}{
+		"action":   "receive",
+		"channel":  ref("#/channels/" + service),
+		"messages": operationMessages,
+	}
+}
+
+func message(evt events.Event, version string) map[string]interface{} {
+	msg := map[string]interface{}{
+		"name":        evt.Name,
+		"title":       evt.Name,
+		"contentType": contentType,
+	}
+	if evt.Description != "" {
+		msg["summary"] = evt.Description
+		msg["description"] = evt.Description
+	}
+
+	schema := jsonschema.Convert(evt.Schema, jsonschema.Options{
+		Dialect: jsonschema.DialectDraft07,
+		ID:      jsonschema.EventID(evt.Name, evt.Version),
+	})
+	if version == Version26 {
+		msg["schemaFormat"] = schemaFormat
+		msg["payload"] = schema
+	} else {
+		msg["payload"] = map[string]interface{}{
+			"schemaFormat": schemaFormat,
+			"schema":       schema,
+		}
+	}
+
+	if len(evt.Examples) > 0 {
+		examples := make([]interface{}, len(evt.Examples))
+		for n, example := range evt.Examples {
+			item := map[string]interface{}{"payload": example}
+			if evt.ExamplesGenerated {
+				// Mark examples generated from the schema, so that they
+				// aren't mistaken for captured events.
+				item["summary"] = generatedSummary
+				item["x-generated"] = true
+			}
+			examples[n] = item
+		}
+		msg["examples"] = examples
+	}
+	return msg
+}
+
+// operationID returns the ID of the operation receiving a service's events,
+// eg. "receiveStripe".
+func operationID(service string) string {
+	if service == "" {
+		return "receive"
+	}
+	return "receive" + strings.ToUpper(service[:1]) + service[1:]
+}
+
+func ref(to string) map[string]interface{} {
+	return map[string]interface{}{"$ref": to}
+}
@@ -0,0 +1,182 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"cuelang.org/go/cue"
+	cuejson "cuelang.org/go/encoding/json"
+	"github.com/inngest/event-schemas/events"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var testEvents = []events.Event{
+	{
+		Name:        "acme/user.created",
+		Service:     "acme",
+		Description: "A user signed up",
+		Schema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"name"},
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "string", "enum": []interface{}{"acme/user.created"}},
+			},
+		},
+		Examples: []map[string]interface{}{{"name": "acme/user.created"}},
+	},
+	{
+		Name:              "acme/user.created",
+		Service:           "acme",
+		Version:           "2",
+		Schema:            map[string]interface{}{"type": "object"},
+		Examples:          []map[string]interface{}{{"name": "acme/user.created", "v": "2"}},
+		ExamplesGenerated: true,
+	},
+	{
+		Name:   "ping",
+		Schema: map[string]interface{}{"type": "object"},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generate(testEvents, Options{})
+	require.NoError(t, err)
+	require.Equal(t, Version26, doc.AsyncAPI)
+	require.Equal(t, "Inngest events", doc.Info.Title)
+
+	// One channel per service, with one message per event.
+	require.Len(t, doc.Channels, 2)
+	require.Equal(t, map[string]interface{}{
+		"description": "Events sent by acme.",
+		"subscribe": map[string]interface{}{
+			"operationId": "receiveAcme",
+			"message": map[string]interface{}{
+				"oneOf": []interface{}{
+					map[string]interface{}{"$ref": "#/components/messages/acme.user.created"},
+					map[string]interface{}{"$ref": "#/components/messages/acme.user.created.v2"},
+				},
+			},
+		},
+	}, doc.Channels["acme"])
+	require.Contains(t, doc.Channels, "events")
+
+	msg := doc.Components.Messages["acme.user.created"].(map[string]interface{})
+	require.Equal(t, "acme/user.created", msg["name"])
+	require.Equal(t, "A user signed up", msg["description"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"payload": map[string]interface{}{"name": "acme/user.created"}},
+	}, msg["examples"])
+
+	// Generated examples are marked as such.
+	generated := doc.Components.Messages["acme.user.created.v2"].(map[string]interface{})
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"payload":     map[string]interface{}{"name": "acme/user.created", "v": "2"},
+			"summary":     "Generated from the schema with fake data",
+			"x-generated": true,
+		},
+	}, generated["examples"])
+
+	// Payloads are standalone draft-07 schemas.
+	require.Equal(t, "application/schema+json;version=draft-07", msg["schemaFormat"])
+	payload := msg["payload"].(map[string]interface{})
+	require.Equal(t, "http://json-schema.org/draft-07/schema#", payload["$schema"])
+	require.Equal(t, "urn:inngest:event:acme/user.created", payload["$id"])
+	require.Equal(t, "urn:inngest:event:acme/user.created:2", generated["payload"].(map[string]interface{})["$id"])
+	require.Equal(t, "acme/user.created", payload["properties"].(map[string]interface{})["name"].(map[string]interface{})["const"])
+
+	// YAML and JSON encode the same document.
+	byt, err := doc.JSON()
+	require.NoError(t, err)
+	fromJSON := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(byt, &fromJSON))
+
+	yml, err := doc.YAML()
+	require.NoError(t, err)
+	fromYAML := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(yml, &fromYAML))
+
+	byt, err = json.Marshal(fromYAML)
+	require.NoError(t, err)
+	roundtrip := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(byt, &roundtrip))
+	require.Equal(t, fromJSON, roundtrip)
+}
+
+func TestGenerateVersion30(t *testing.T) {
+	doc, err := Generate(testEvents, Options{Version: Version30})
+	require.NoError(t, err)
+	require.Equal(t, Version30, doc.AsyncAPI)
+
+	channel := doc.Channels["acme"].(map[string]interface{})
+	require.Equal(t, "acme", channel["address"])
+	require.Contains(t, channel["messages"], "acme.user.created.v2")
+
+	require.Equal(t, map[string]interface{}{
+		"action":  "receive",
+		"channel": map[string]interface{}{"$ref": "#/channels/acme"},
+		"messages": []interface{}{
+			map[string]interface{}{"$ref": "#/channels/acme/messages/acme.user.created"},
+			map[string]interface{}{"$ref": "#/channels/acme/messages/acme.user.created.v2"},
+		},
+	}, doc.Operations["receiveAcme"])
+
+	msg := doc.Components.Messages["ping"].(map[string]interface{})
+	require.Equal(t, schemaFormat, msg["payload"].(map[string]interface{})["schemaFormat"])
+}
+
+func TestGenerateErrors(t *testing.T) {
+	_, err := Generate(testEvents, Options{Version: "1.0.0"})
+	require.Error(t, err)
+
+	_, err = Generate([]events.Event{testEvents[0], testEvents[0]}, Options{})
+	require.Error(t, err)
+}
+
+func TestGenerateCatalog(t *testing.T) {
+	doc, err := Generate(events.Events, Options{})
+	require.NoError(t, err)
+	require.Len(t, doc.Components.Messages, len(events.Events))
+	for _, evt := range events.Events {
+		require.Contains(t, doc.Channels, evt.Service)
+	}
+}
+
+// TestValidDocuments validates documents against the AsyncAPI schemas.
+func TestValidDocuments(t *testing.T) {
+	byt, err := os.ReadFile("./testdata/asyncapi.cue")
+	require.NoError(t, err)
+	r := &cue.Runtime{}
+	inst, err := r.Compile("asyncapi.cue", string(byt))
+	require.NoError(t, err)
+	schema := inst.Value().LookupDef("#Document")
+	require.NoError(t, schema.Err())
+
+	validate := func(doc Document) error {
+		byt, err := doc.JSON()
+		require.NoError(t, err)
+		expr, err := cuejson.Extract("asyncapi.json", byt)
+		require.NoError(t, err)
+		return schema.Unify(schema.Context().BuildExpr(expr)).Validate(cue.Concrete(true))
+	}
+
+	for _, version := range []string{Version26, Version30} {
+		for _, evts := range [][]events.Event{testEvents, events.Events} {
+			doc, err := Generate(evts, Options{Version: version})
+			require.NoError(t, err)
+			require.NoError(t, validate(doc), version)
+		}
+	}
+
+	// Draft 2020-12 payloads aren't valid.
+	doc, err := Generate(testEvents, Options{})
+	require.NoError(t, err)
+	msg := doc.Components.Messages["ping"].(map[string]interface{})
+	msg["schemaFormat"] = "application/schema+json;version=draft-2020-12"
+	require.Error(t, validate(doc))
+	msg["schemaFormat"] = schemaFormat
+	msg["payload"] = map[string]interface{}{"$defs": map[string]interface{}{}}
+	require.Error(t, validate(doc))
+}
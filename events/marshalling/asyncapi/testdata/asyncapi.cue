@@ -0,0 +1,181 @@
+// The parts of the AsyncAPI 2.6.0 and 3.0.0 JSON schemas which generated
+// documents use, from https://github.com/asyncapi/spec-json-schemas.
+// Definitions are closed, as within the JSON schemas, and allow specification
+// extensions.
+
+#Document: #Document26 | #Document30
+
+#Document26: {
+	#Extensions
+	asyncapi:            "2.6.0"
+	info:                #Info
+	defaultContentType?: string
+	channels: [string]: #Channel26
+	components?: {
+		messages?: [=~"^[\\w\\d\\.\\-_]+$"]: #Message26
+	}
+}
+
+#Channel26: {
+	#Extensions
+	description?: string
+	subscribe?:   #Operation26
+	publish?:     #Operation26
+}
+
+#Operation26: {
+	#Extensions
+	operationId?: string
+	summary?:     string
+	description?: string
+	message?:     #Reference | #Message26 | {oneOf: [...(#Reference | #Message26)]}
+}
+
+#Message26: {
+	#Extensions
+	schemaFormat?: #SchemaFormat26
+	contentType?:  string
+	payload?:      #Schema
+	name?:         string
+	title?:        string
+	summary?:      string
+	description?:  string
+	examples?: [...#MessageExample]
+}
+
+// #SchemaFormat26 lists the JSON schema formats which AsyncAPI 2.6 supports.
+#SchemaFormat26:
+	"application/vnd.aai.asyncapi;version=2.6.0" |
+	"application/vnd.aai.asyncapi+json;version=2.6.0" |
+	"application/vnd.aai.asyncapi+yaml;version=2.6.0" |
+	"application/schema+json;version=draft-07" |
+	"application/schema+yaml;version=draft-07"
+
+#Document30: {
+	#Extensions
+	asyncapi:            "3.0.0"
+	info:                #Info
+	defaultContentType?: string
+	channels: [string]: #Channel30
+	operations?: [string]: #Operation30
+	components?: {
+		messages?: [=~"^[\\w\\d\\.\\-_]+$"]: #Message30
+	}
+}
+
+#Channel30: {
+	#Extensions
+	address?:     string | null
+	description?: string
+	messages?: [=~"^[\\w\\d\\.\\-_]+$"]: #Reference | #Message30
+}
+
+#Operation30: {
+	#Extensions
+	action:       "send" | "receive"
+	channel:      #Reference
+	description?: string
+	messages?: [...#Reference]
+}
+
+// #Message30 is an AsyncAPI 3.0 message, whose payload may specify its
+// format.
+#Message30: {
+	#Extensions
+	contentType?: string
+	payload?:     #Schema | #MultiFormatSchema
+	name?:        string
+	title?:       string
+	summary?:     string
+	description?: string
+	examples?: [...#MessageExample]
+}
+
+#MultiFormatSchema: {
+	#Extensions
+	schemaFormat?: #SchemaFormat30
+	schema:        #Schema
+}
+
+// #SchemaFormat30 lists the JSON schema formats which AsyncAPI 3.0 supports.
+#SchemaFormat30:
+	"application/vnd.aai.asyncapi;version=3.0.0" |
+	"application/vnd.aai.asyncapi+json;version=3.0.0" |
+	"application/vnd.aai.asyncapi+yaml;version=3.0.0" |
+	"application/schema+json;version=draft-07" |
+	"application/schema+yaml;version=draft-07"
+
+#Extensions: [=~"^x-[\\w\\d\\.\\x2d_]+$"]: _
+
+#Info: {
+	#Extensions
+	title:        string
+	version:      string
+	description?: string
+}
+
+#Reference: {
+	$ref: string
+}
+
+#MessageExample: {
+	#Extensions
+	headers?: {...}
+	payload?: _
+	name?:    string
+	summary?: string
+}
+
+// #Schema is a JSON schema draft-07 schema.  Unlike the draft-07
+// meta-schema, it's closed so that keywords from later drafts, which draft-07
+// validators ignore, are rejected.
+#Schema: bool | {
+	$schema?:          "http://json-schema.org/draft-07/schema#"
+	$id?:              string
+	$ref?:             =~"^#/definitions/"
+	$comment?:         string
+	title?:            string
+	description?:      string
+	default?:          _
+	readOnly?:         bool
+	writeOnly?:        bool
+	examples?:         [...]
+	multipleOf?:       number & >0
+	maximum?:          number
+	exclusiveMaximum?: number
+	minimum?:          number
+	exclusiveMinimum?: number
+	maxLength?:        int & >=0
+	minLength?:        int & >=0
+	pattern?:          string
+	additionalItems?:  #Schema
+	items?:            #Schema | [...#Schema]
+	maxItems?:         int & >=0
+	minItems?:         int & >=0
+	uniqueItems?:      bool
+	contains?:         #Schema
+	maxProperties?:    int & >=0
+	minProperties?:    int & >=0
+	required?: [...string]
+	additionalProperties?: #Schema
+	definitions?: [string]:       #Schema
+	properties?: [string]:        #Schema
+	patternProperties?: [string]: #Schema
+	dependencies?: [string]:      #Schema | [...string]
+	propertyNames?:    #Schema
+	const?:            _
+	enum?:             [..._]
+	type?:             #Type | [...#Type]
+	format?:           string
+	contentMediaType?: string
+	contentEncoding?:  string
+	if?:               #Schema
+	then?:             #Schema
+	else?:             #Schema
+	allOf?: [...#Schema]
+	anyOf?: [...#Schema]
+	oneOf?: [...#Schema]
+	not?: #Schema
+}
+
+#Type: "array" | "boolean" | "integer" | "null" | "number" | "object" | "string"
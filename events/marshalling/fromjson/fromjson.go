@@ -1,6 +1,8 @@
 package fromjson
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math"
 
@@ -8,9 +10,29 @@ import (
 	"cuelang.org/go/cue/ast"
 	"cuelang.org/go/cue/format"
 	"cuelang.org/go/cue/token"
+	"github.com/inngest/event-schemas/events/marshalling/internal/orderedjson"
 	"github.com/inngest/event-schemas/pkg/cueutil"
 )
 
+// Options configures how types are generated from JSON.
+type Options struct {
+	// SortKeys sorts the fields of each generated struct alphabetically.  By
+	// default, fields retain the order of keys within the JSON source.
+	SortKeys bool
+
+	// Formats constrains strings which are in a well-known format, such as
+	// RFC3339 timestamps, UUIDs, emails, URLs and IP addresses, to a regular
+	// expression matching the format, eg. `string & =~"^...$"`.  Each string
+	// at a given path must have the same format.
+	Formats bool
+
+	// Enums infers low-cardinality string fields as a disjunction of each
+	// value seen, eg. `"active" | "inactive"`.  This is only used when
+	// inferring types from many samples, as an enum can't be inferred from
+	// a single value.
+	Enums bool
+}
+
 // FromJSON takes a JSON map and generates a CUE type which validates the given
 // values.  Because this works backwards from values, it will never generate
 // constraints and will likely contain errors with eg. null values. However,
@@ -23,10 +45,39 @@ import (
 //    name: string
 // }
 //
-// NOTE: Because maps are unordered in Go, the resulting type will have fields
-// returned in no specific order.  The type will, however, match the iteration
-// order from Go.
+// NOTE: Because maps are unordered in Go, the resulting type has fields sorted
+// alphabetically.  Use FromJSONBytes to retain the order of keys within the
+// JSON source.
 func FromJSON(input map[string]interface{}) (typeDef string, err error) {
+	return fromObject(orderedjson.FromValue(input).(*orderedjson.Object), Options{})
+}
+
+// FromJSONBytes takes a JSON object and generates a CUE type which validates
+// the object, as with FromJSON.  The generated type's fields retain the order
+// of keys within the JSON source, unless Options.SortKeys is set.
+//
+// With Options.Formats, strings in well-known formats are constrained to the
+// format.
+func FromJSONBytes(byt []byte, o Options) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(byt))
+	val, err := orderedjson.Decode(dec)
+	if err != nil {
+		return "", fmt.Errorf("error parsing JSON: %w", err)
+	}
+	if dec.More() {
+		return "", fmt.Errorf("error parsing JSON: unexpected data after object")
+	}
+	obj, ok := val.(*orderedjson.Object)
+	if !ok {
+		return "", fmt.Errorf("error parsing JSON: expected an object")
+	}
+	if o.SortKeys {
+		orderedjson.SortKeys(obj)
+	}
+	return fromObject(obj, o)
+}
+
+func fromObject(input *orderedjson.Object, o Options) (typeDef string, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("error generating type from JSON: %v", r)
@@ -39,6 +90,12 @@ func FromJSON(input map[string]interface{}) (typeDef string, err error) {
 	// Walk the type recursively, adding fields to the type definition/.
 	walk(input, def)
 
+	if o.Formats {
+		s := newStats()
+		s.observe(input, "")
+		s.refine(def, "", Options{Formats: true})
+	}
+
 	// Format the cue code.
 	byt, err := format.Node(
 		def,
@@ -74,9 +131,9 @@ func FromJSON(input map[string]interface{}) (typeDef string, err error) {
 //	node := i.Value().Source()
 //	// inspect the AST.
 //	spew.Dump(node)
-func walk(obj map[string]interface{}, def *ast.StructLit) {
-
-	for k, v := range obj {
+func walk(obj *orderedjson.Object, def *ast.StructLit) {
+	for _, k := range obj.Keys {
+		v := obj.Values[k]
 		typ := kind(v)
 
 		// Generate a field for this key in the struct.
@@ -144,7 +201,7 @@ func walk(obj map[string]interface{}, def *ast.StructLit) {
 		case cue.StructKind:
 			// Create a new struct and walk the map
 			inner := ast.NewStruct()
-			walk(v.(map[string]interface{}), inner)
+			walk(v.(*orderedjson.Object), inner)
 			value = inner
 		default:
 			// by default this is a basic type, eg "string".  Use
@@ -191,7 +248,7 @@ func walkSlice(slice []interface{}) (cue.Kind, []*ast.StructLit) {
 		if k == cue.StructKind {
 			// Map the type of this struct.
 			structAST := ast.NewStruct()
-			walk(item.(map[string]interface{}), structAST)
+			walk(item.(*orderedjson.Object), structAST)
 			structs = append(structs, structAST)
 		}
 
@@ -210,7 +267,7 @@ func walkSlice(slice []interface{}) (cue.Kind, []*ast.StructLit) {
 	}
 
 	// Deduplicate struct definitions by seeing which are subsumable.
-	// We can't rely on ASTs as maps have randomized key ordering.
+	// We can't rely on ASTs as objects may list keys in differing orders.
 	r := &cue.Runtime{}
 
 	deduped := []*ast.StructLit{}
@@ -261,7 +318,7 @@ func kind(v interface{}) cue.Kind {
 		return cue.BoolKind
 	case string:
 		return cue.StringKind
-	case map[string]interface{}:
+	case *orderedjson.Object:
 		return cue.StructKind
 	case []interface{}:
 		return cue.ListKind
@@ -2,7 +2,6 @@ package fromjson
 
 import (
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -33,41 +32,64 @@ func TestFromJSON(t *testing.T) {
 		expected, err := ioutil.ReadFile(path.Join("./testdata", strings.ReplaceAll(name, ".json", ".cue")))
 		require.NoError(t, err)
 
+		// Fields retain the order of keys within the JSON source.
+		actual, err := FromJSONBytes(contents, Options{})
+		require.NoError(t, err)
+		require.Equal(t, strings.TrimSpace(string(expected)), strings.TrimSpace(actual), name)
+
+		// Maps have no order, so FromJSON sorts fields alphabetically.
 		data := map[string]interface{}{}
 		err = json.Unmarshal(contents, &data)
 		require.NoError(t, err)
 
-		actual, err := FromJSON(data)
+		fromMap, err := FromJSON(data)
 		require.NoError(t, err)
-		require.True(t, len(actual) > 2, "no type generated: %s", actual)
-
-		// NOTE: Golang maps do not retain any order.  This means that the
-		// values are bound to be different on each test run.  We figure out
-		// whether the types are the same by using Cue's "Subsumes" function,
-		// which basically means "are the types the same?"
+		sorted, err := FromJSONBytes(contents, Options{SortKeys: true})
+		require.NoError(t, err)
+		require.Equal(t, sorted, fromMap, name)
 
+		// Sorting fields doesn't change the type.
 		r := &cue.Runtime{}
 		instA, err := r.Compile(".", expected)
 		require.NoError(t, err)
 
-		instB, err := r.Compile(".", actual)
+		instB, err := r.Compile(".", sorted)
 		require.NoError(t, err)
 
-		matches := instA.Value().Subsumes(instB.Value())
+		require.True(t, instA.Value().Subsumes(instB.Value()), "generated types do not match (got %s)", sorted)
+		require.True(t, instB.Value().Subsumes(instA.Value()), "generated types do not reverse-match (got %s)", sorted)
+	}
+}
 
-		if !matches {
-			fmt.Println(actual)
-		}
+func TestFromJSONBytesErrors(t *testing.T) {
+	_, err := FromJSONBytes([]byte(`[1, 2]`), Options{})
+	require.Error(t, err)
 
-		require.True(t, matches, "generated types do not match (got %s)", actual)
+	_, err = FromJSONBytes([]byte(`{"a": `), Options{})
+	require.Error(t, err)
 
-		// Ensure B also subsumes A so that they're the same.
-		matches = instB.Value().Subsumes(instA.Value())
-		if !matches {
-			fmt.Println(actual)
-		}
-		require.True(t, matches, "generated types do not reverse-match (got %s)", actual)
-	}
+	_, err = FromJSONBytes([]byte(`{"a": 1} {"b": 2}`), Options{})
+	require.Error(t, err)
+}
+
+func TestFromJSONBytesFormats(t *testing.T) {
+	actual, err := FromJSONBytes([]byte(`{
+		"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		"at": "2022-05-01T10:00:00Z",
+		"ips": ["10.0.0.1", "10.0.0.2"],
+		"name": "Acme",
+		"note": null
+	}`), Options{Formats: true})
+	require.NoError(t, err)
+
+	expected := `{
+  id: string & =~"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"
+  at: string & =~"^\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}(\\.\\d+)?(Z|[+-]\\d{2}:\\d{2})$"
+  ips: [...string & =~"^((25[0-5]|2[0-4]\\d|1\\d\\d|[1-9]?\\d)\\.){3}(25[0-5]|2[0-4]\\d|1\\d\\d|[1-9]?\\d)$"]
+  name: string
+  note: _
+}`
+	require.Equal(t, expected, strings.TrimSpace(actual))
 }
 
 const input = ``
@@ -0,0 +1,295 @@
+package fromjson
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/token"
+	"github.com/inngest/event-schemas/events/marshalling/internal/orderedjson"
+	"github.com/inngest/event-schemas/pkg/formats"
+)
+
+const (
+	// maxEnumValues is the maximum number of distinct values a string field
+	// may have to be inferred as an enum.
+	maxEnumValues = 5
+	// minEnumRepeats is the minimum number of times, on average, that each
+	// distinct value must be seen for a string field to be inferred as an
+	// enum.  This prevents eg. IDs from becoming enums given few samples.
+	minEnumRepeats = 2
+)
+
+// stats records the scalar values seen at each path within JSON samples, so
+// that inferred types can be refined with information from every sample.
+type stats struct {
+	fields map[string]*fieldStats
+}
+
+type fieldStats struct {
+	// kinds lists the non-null scalar kinds seen, in the order first seen.
+	kinds []cue.Kind
+	// nulls is the number of null values seen.
+	nulls int
+	// strings is the number of string values seen.
+	strings int
+	// values lists the distinct string values seen, in the order first seen,
+	// up to maxEnumValues+1 values.
+	values []string
+	// format is the format of every string seen, if every string has the
+	// same format.
+	format formats.Format
+	// mixed is true if strings have differing formats, or no format.
+	mixed bool
+}
+
+func newStats() *stats {
+	return &stats{fields: map[string]*fieldStats{}}
+}
+
+// observe records every scalar within the value, using the same paths as
+// Inferred.Counts.
+func (s *stats) observe(v interface{}, prefix string) {
+	switch t := v.(type) {
+	case *orderedjson.Object:
+		for _, k := range t.Keys {
+			s.observe(t.Values[k], join(prefix, k))
+		}
+		return
+	case []interface{}:
+		for _, item := range t {
+			s.observe(item, prefix+"[*]")
+		}
+		return
+	}
+
+	f, ok := s.fields[prefix]
+	if !ok {
+		f = &fieldStats{}
+		s.fields[prefix] = f
+	}
+
+	if v == nil {
+		f.nulls++
+		return
+	}
+
+	k := kind(v)
+	if !f.has(k) {
+		f.kinds = append(f.kinds, k)
+	}
+
+	str, ok := v.(string)
+	if !ok {
+		return
+	}
+	f.strings++
+	if len(f.values) <= maxEnumValues && !contains(f.values, str) {
+		f.values = append(f.values, str)
+	}
+	format, ok := formats.Detect(str)
+	switch {
+	case !ok || (f.format != "" && f.format != format):
+		f.mixed = true
+	default:
+		f.format = format
+	}
+}
+
+func (f *fieldStats) has(k cue.Kind) bool {
+	for _, existing := range f.kinds {
+		if existing == k {
+			return true
+		}
+	}
+	return false
+}
+
+// enum returns whether the field's strings should be inferred as an enum.
+func (f *fieldStats) enum() bool {
+	n := len(f.values)
+	return n > 0 && n <= maxEnumValues && f.strings >= n*minEnumRepeats
+}
+
+// refine rewrites the scalar types within the given expression using the
+// values seen at each path, returning the refined expression.  Structs and
+// lists are refined recursively.
+//
+// Scalars are rewritten as a disjunction of each kind seen, in the order
+// first seen.  Depending on the options, strings are constrained to their
+// format or inferred as an enum.  Fields which are null within some samples
+// become "T | null";  fields which are only ever null remain "_".
+func (s *stats) refine(expr ast.Expr, path string, o Options) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.StructLit:
+		for _, elt := range t.Elts {
+			field, ok := elt.(*ast.Field)
+			if !ok {
+				continue
+			}
+			label, _, err := ast.LabelName(field.Label)
+			if err != nil {
+				continue
+			}
+			field.Value = s.refine(field.Value, join(path, label), o)
+		}
+		return t
+	case *ast.ListLit:
+		for _, elt := range t.Elts {
+			if e, ok := elt.(*ast.Ellipsis); ok && e.Type != nil {
+				e.Type = s.refine(e.Type, path+"[*]", o)
+			}
+		}
+		return t
+	case *ast.ParenExpr:
+		return s.refine(t.X, path, o)
+	}
+
+	f, ok := s.fields[path]
+	if !ok {
+		// There are no scalars at this path, eg. the path is only ever
+		// a list.
+		f = &fieldStats{}
+	}
+
+	// Replace every scalar within the disjunction with the scalars seen,
+	// retaining structs and lists.
+	var (
+		result   []ast.Expr
+		scalars  = f.exprs(o)
+		replaced = false
+	)
+	for _, d := range disjuncts(expr) {
+		switch d.(type) {
+		case *ast.StructLit, *ast.ListLit:
+			result = append(result, s.refine(d, path, o))
+		default:
+			if !replaced {
+				result = append(result, scalars...)
+				replaced = true
+			}
+		}
+	}
+	if !replaced {
+		result = append(result, scalars...)
+	}
+	if f.nulls > 0 && len(result) > 0 {
+		result = append(result, &ast.BasicLit{Kind: token.NULL, Value: "null"})
+	}
+	if len(result) == 0 {
+		return ast.NewIdent("_")
+	}
+
+	// Remove empty lists if there's another list type, as the other list
+	// type already allows empty lists.
+	if empty := countEmptyLists(result); empty > 0 && empty < countLists(result) {
+		filtered := result[:0]
+		for _, d := range result {
+			if !isEmptyList(d) {
+				filtered = append(filtered, d)
+			}
+		}
+		result = filtered
+	}
+
+	return ast.NewBinExpr(token.OR, result...)
+}
+
+// exprs returns the expressions for the non-null scalars seen.
+func (f *fieldStats) exprs(o Options) []ast.Expr {
+	exprs := []ast.Expr{}
+	for _, k := range f.kinds {
+		if k != cue.StringKind {
+			exprs = append(exprs, ast.NewIdent(k.String()))
+			continue
+		}
+
+		switch {
+		case o.Formats && !f.mixed && f.format != "":
+			exprs = append(exprs, formatExpr(f.format))
+		case o.Enums && f.enum():
+			for _, v := range f.values {
+				exprs = append(exprs, ast.NewString(v))
+			}
+		default:
+			exprs = append(exprs, ast.NewIdent("string"))
+		}
+	}
+	return exprs
+}
+
+// formatExpr returns a string constrained to the given format, eg.
+// `string & =~"^...$"`.
+func formatExpr(f formats.Format) ast.Expr {
+	return ast.NewBinExpr(
+		token.AND,
+		ast.NewIdent("string"),
+		&ast.UnaryExpr{Op: token.MAT, X: ast.NewString(formats.Pattern(f))},
+	)
+}
+
+// disjuncts returns each element of a disjunction, or the expression itself
+// if the expression isn't a disjunction.
+func disjuncts(expr ast.Expr) []ast.Expr {
+	switch t := expr.(type) {
+	case *ast.BinaryExpr:
+		if t.Op == token.OR {
+			return append(disjuncts(t.X), disjuncts(t.Y)...)
+		}
+	case *ast.ParenExpr:
+		return disjuncts(t.X)
+	}
+	return []ast.Expr{expr}
+}
+
+// isEmptyList returns whether the expression is a list with no element type,
+// ie. "[...]", which is inferred from empty lists.
+func isEmptyList(expr ast.Expr) bool {
+	l, ok := expr.(*ast.ListLit)
+	if !ok || len(l.Elts) != 1 {
+		return false
+	}
+	e, ok := l.Elts[0].(*ast.Ellipsis)
+	if !ok {
+		return false
+	}
+	if e.Type == nil {
+		return true
+	}
+	ident, ok := e.Type.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
+
+func countEmptyLists(exprs []ast.Expr) int {
+	n := 0
+	for _, e := range exprs {
+		if isEmptyList(e) {
+			n++
+		}
+	}
+	return n
+}
+
+func countLists(exprs []ast.Expr) int {
+	n := 0
+	for _, e := range exprs {
+		if _, ok := e.(*ast.ListLit); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
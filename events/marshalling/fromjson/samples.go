@@ -0,0 +1,121 @@
+package fromjson
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"github.com/inngest/event-schemas/events/marshalling/internal/orderedjson"
+	"github.com/inngest/event-schemas/pkg/cueutil"
+	"github.com/inngest/event-schemas/pkg/merge"
+)
+
+// Inferred is a cue type inferred from many JSON samples.
+type Inferred struct {
+	// Cue is the cue type which validates every sample.  Fields which are
+	// missing from some samples are optional, and fields with differing
+	// types across samples are unions of each type.
+	Cue string
+	// Samples is the number of samples read.
+	Samples int
+	// Counts stores the number of samples containing each field, keyed by
+	// the field's path, eg. "data.user.id".  Fields within lists of objects
+	// use "[*]" in place of the index, eg. "data.commits[*].id".
+	Counts map[string]int
+}
+
+// FromSamples reads a stream of JSON objects, eg. JSONL, inferring a type for
+// each sample and merging each type into a single type which validates every
+// sample.  Samples are decoded one at a time, so the reader may contain an
+// arbitrary number of samples.
+//
+// Fields are ordered as they're first seen within the samples.  Fields which
+// are null within some samples are inferred as "T | null".
+func FromSamples(r io.Reader) (Inferred, error) {
+	return FromSamplesWithOptions(r, Options{})
+}
+
+// FromSamplesWithOptions infers a type from a stream of JSON objects, as with
+// FromSamples, using the given options.  Options.Formats and Options.Enums are
+// evaluated across every sample:  a string field is only constrained to a
+// format if every value has the format.
+func FromSamplesWithOptions(r io.Reader, o Options) (Inferred, error) {
+	ctx := context.Background()
+	result := Inferred{Counts: map[string]int{}}
+	s := newStats()
+
+	rt := &cue.Runtime{}
+	var merged cue.Value
+
+	dec := json.NewDecoder(r)
+	for {
+		val, err := orderedjson.Decode(dec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("error decoding sample %d: %w", result.Samples+1, err)
+		}
+		result.Samples++
+
+		sample, ok := val.(*orderedjson.Object)
+		if !ok {
+			return result, fmt.Errorf("error decoding sample %d: expected an object", result.Samples)
+		}
+		if o.SortKeys {
+			orderedjson.SortKeys(sample)
+		}
+		typedef, err := fromObject(sample, Options{})
+		if err != nil {
+			return result, fmt.Errorf("error inferring sample %d: %w", result.Samples, err)
+		}
+		inst, err := rt.Compile(".", typedef)
+		if err != nil {
+			return result, fmt.Errorf("error compiling sample %d: %w", result.Samples, err)
+		}
+		merged, err = merge.Merge(ctx, merged, inst.Value())
+		if err != nil {
+			return result, fmt.Errorf("error merging sample %d: %w", result.Samples, err)
+		}
+
+		// Count each field once per sample, regardless of how many list
+		// items contain the field.
+		seen := map[string]bool{}
+		countFields(sample, "", seen)
+		for path := range seen {
+			result.Counts[path]++
+		}
+		s.observe(sample, "")
+	}
+
+	if result.Samples == 0 {
+		return result, fmt.Errorf("no samples provided")
+	}
+
+	str, err := cueutil.ASTToSyntax(s.refine(merged.Syntax().(ast.Expr), "", o))
+	if err != nil {
+		return result, err
+	}
+	result.Cue = str
+	return result, nil
+}
+
+// countFields marks the path of every field within the value as seen.
+func countFields(v interface{}, prefix string, seen map[string]bool) {
+	switch t := v.(type) {
+	case *orderedjson.Object:
+		for k, item := range t.Values {
+			path := join(prefix, k)
+			seen[path] = true
+			countFields(item, path, seen)
+		}
+	case []interface{}:
+		for _, item := range t {
+			countFields(item, prefix+"[*]", seen)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package fromjson
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSamples(t *testing.T) {
+	f, err := os.Open("./testdata/samples.jsonl")
+	require.NoError(t, err)
+	defer f.Close()
+
+	inferred, err := FromSamples(f)
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("./testdata/samples.cue")
+	require.NoError(t, err)
+
+	// Fields are ordered as they're first seen within the samples.
+	require.Equal(t, strings.TrimSpace(string(expected)), inferred.Cue)
+
+	require.Equal(t, 3, inferred.Samples)
+	require.Equal(t, map[string]int{
+		"name":              3,
+		"data":              3,
+		"data.id":           3,
+		"data.email":        3,
+		"data.tags":         2,
+		"data.plan":         1,
+		"data.orgs":         1,
+		"data.orgs[*].id":   1,
+		"data.orgs[*].role": 1,
+	}, inferred.Counts)
+}
+
+func TestFromSamplesOptions(t *testing.T) {
+	byt, err := os.ReadFile("./testdata/formats.jsonl")
+	require.NoError(t, err)
+
+	inferred, err := FromSamplesWithOptions(bytes.NewReader(byt), Options{Formats: true, Enums: true})
+	require.NoError(t, err)
+
+	// Strings are only constrained when every value has the same format, and
+	// only repeated values become enums.
+	expected, err := os.ReadFile("./testdata/formats.cue")
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSpace(string(expected)), inferred.Cue)
+
+	// Every sample must satisfy the inferred type.
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", inferred.Cue)
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(byt)), "\n") {
+		sample, err := r.Compile(".", line)
+		require.NoError(t, err)
+		require.NoError(t, inst.Value().Unify(sample.Value()).Validate(cue.Concrete(true)), line)
+	}
+}
+
+func TestFromSamplesErrors(t *testing.T) {
+	_, err := FromSamples(strings.NewReader(""))
+	require.Error(t, err)
+
+	_, err = FromSamples(strings.NewReader("{\"id\": 1}\n{\"id\": "))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sample 2")
+}
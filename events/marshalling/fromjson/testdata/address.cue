@@ -1,8 +1,8 @@
 {
-	line_1:       string
-	line_2:       _ | string
-	city:         string
-	state:        string
-	zip:          string
-	country_code: string
+  line_1:       string
+  line_2:       _
+  city:         string
+  state:        string
+  zip:          string
+  country_code: string
 }
@@ -1,16 +1,16 @@
 {
-	line_1:       string
-	line_2:       _
-	city:         string
-	state:        string
-	zip:          string
-	country_code: string
-	int:          int
-	float:        float
-	intslice: [...int]
-	strslice: [...string]
-	mixslice: [...(int | string)]
-	map: {
-		inner: bool
-	}
+  line_1:       string
+  line_2:       _
+  city:         string
+  state:        string
+  zip:          string
+  country_code: string
+  int:          int
+  float:        float
+  intslice: [...int]
+  strslice: [...string]
+  mixslice: [...(int|string)]
+  map: {
+    inner: bool
+  }
 }
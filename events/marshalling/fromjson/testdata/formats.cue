@@ -0,0 +1,9 @@
+{
+  id:         string & =~"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"
+  status:     "active" | "inactive"
+  created_at: string & =~"^\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}(\\.\\d+)?(Z|[+-]\\d{2}:\\d{2})$"
+  email:      string & =~"^[^@\\s]+@[^@\\s]+\\.[^@\\s]+$" | null
+  ip:         string
+  note:       string | null
+  links: [...string & =~"^https?://[^\\s/$.?#][^\\s]*$"]
+}
@@ -1,490 +1,490 @@
 {
-	data: {
-		action: string
-		number: int
-		organization: {
-			description:        string
-			events_url:         string
-			login:              string
-			public_members_url: string
-			repos_url:          string
-			url:                string
-			avatar_url:         string
-			id:                 int
-			issues_url:         string
-			members_url:        string
-			node_id:            string
-			hooks_url:          string
-		}
-		pull_request: {
-			diff_url: string
-			labels: [...]
-			title:        string
-			body:         string
-			closed_at:    _
-			deletions:    int
-			commits_url:  string
-			merged_at:    _
-			statuses_url: string
-			user: {
-				events_url:          string
-				node_id:             string
-				organizations_url:   string
-				type:                string
-				url:                 string
-				following_url:       string
-				gists_url:           string
-				html_url:            string
-				repos_url:           string
-				followers_url:       string
-				id:                  int
-				site_admin:          bool
-				starred_url:         string
-				subscriptions_url:   string
-				avatar_url:          string
-				gravatar_id:         string
-				login:               string
-				received_events_url: string
-			}
-			author_association: string
-			base: {
-				label: string
-				ref:   string
-				repo: {
-					branches_url:    string
-					name:            string
-					subscribers_url: string
-					svn_url:         string
-					topics: [...]
-					allow_merge_commit:     bool
-					git_url:                string
-					releases_url:           string
-					assignees_url:          string
-					events_url:             string
-					full_name:              string
-					private:                bool
-					trees_url:              string
-					updated_at:             string
-					watchers_count:         int
-					allow_rebase_merge:     bool
-					issue_comment_url:      string
-					issue_events_url:       string
-					milestones_url:         string
-					watchers:               int
-					disabled:               bool
-					downloads_url:          string
-					license:                _
-					merges_url:             string
-					teams_url:              string
-					allow_squash_merge:     bool
-					collaborators_url:      string
-					commits_url:            string
-					contents_url:           string
-					languages_url:          string
-					mirror_url:             _
-					visibility:             string
-					allow_auto_merge:       bool
-					archive_url:            string
-					has_downloads:          bool
-					size:                   int
-					ssh_url:                string
-					statuses_url:           string
-					allow_forking:          bool
-					contributors_url:       string
-					default_branch:         string
-					fork:                   bool
-					forks_url:              string
-					git_refs_url:           string
-					keys_url:               string
-					subscription_url:       string
-					tags_url:               string
-					created_at:             string
-					forks_count:            int
-					has_wiki:               bool
-					open_issues:            int
-					open_issues_count:      int
-					is_template:            bool
-					allow_update_branch:    bool
-					archived:               bool
-					forks:                  int
-					git_commits_url:        string
-					has_issues:             bool
-					has_pages:              bool
-					html_url:               string
-					issues_url:             string
-					blobs_url:              string
-					compare_url:            string
-					git_tags_url:           string
-					labels_url:             string
-					language:               string
-					delete_branch_on_merge: bool
-					notifications_url:      string
-					stargazers_count:       int
-					clone_url:              string
-					has_projects:           bool
-					id:                     int
-					pulls_url:              string
-					owner: {
-						node_id:             string
-						organizations_url:   string
-						repos_url:           string
-						events_url:          string
-						html_url:            string
-						login:               string
-						avatar_url:          string
-						type:                string
-						subscriptions_url:   string
-						following_url:       string
-						id:                  int
-						received_events_url: string
-						site_admin:          bool
-						starred_url:         string
-						url:                 string
-						followers_url:       string
-						gists_url:           string
-						gravatar_id:         string
-					}
-					comments_url:    string
-					description:     string
-					homepage:        _
-					pushed_at:       string
-					stargazers_url:  string
-					deployments_url: string
-					hooks_url:       string
-					node_id:         string
-					url:             string
-				}
-				sha: string
-				user: {
-					events_url:          string
-					followers_url:       string
-					following_url:       string
-					gravatar_id:         string
-					starred_url:         string
-					subscriptions_url:   string
-					site_admin:          bool
-					type:                string
-					node_id:             string
-					organizations_url:   string
-					repos_url:           string
-					avatar_url:          string
-					gists_url:           string
-					html_url:            string
-					id:                  int
-					login:               string
-					received_events_url: string
-					url:                 string
-				}
-			}
-			changed_files: int
-			milestone:     _
-			node_id:       string
-			number:        int
-			requested_teams: [...]
-			comments_url:       string
-			mergeable_state:    string
-			merged:             bool
-			locked:             bool
-			mergeable:          _
-			merged_by:          _
-			patch_url:          string
-			rebaseable:         _
-			active_lock_reason: _
-			created_at:         string
-			head: {
-				label: string
-				ref:   string
-				repo: {
-					pulls_url:         string
-					releases_url:      string
-					compare_url:       string
-					contributors_url:  string
-					git_commits_url:   string
-					issue_events_url:  string
-					license:           _
-					private:           bool
-					updated_at:        string
-					url:               string
-					has_projects:      bool
-					keys_url:          string
-					language:          string
-					notifications_url: string
-					pushed_at:         string
-					size:              int
-					allow_auto_merge:  bool
-					git_tags_url:      string
-					html_url:          string
-					id:                int
-					languages_url:     string
-					topics: [...]
-					collaborators_url: string
-					created_at:        string
-					has_downloads:     bool
-					has_issues:        bool
-					is_template:       bool
-					name:              string
-					allow_forking:     bool
-					commits_url:       string
-					contents_url:      string
-					default_branch:    string
-					forks:             int
-					owner: {
-						starred_url:         string
-						subscriptions_url:   string
-						type:                string
-						node_id:             string
-						site_admin:          bool
-						organizations_url:   string
-						repos_url:           string
-						gists_url:           string
-						id:                  int
-						events_url:          string
-						login:               string
-						following_url:       string
-						gravatar_id:         string
-						html_url:            string
-						received_events_url: string
-						url:                 string
-						avatar_url:          string
-						followers_url:       string
-					}
-					allow_merge_commit:     bool
-					archived:               bool
-					forks_url:              string
-					issues_url:             string
-					subscribers_url:        string
-					svn_url:                string
-					tags_url:               string
-					visibility:             string
-					allow_squash_merge:     bool
-					milestones_url:         string
-					watchers:               int
-					comments_url:           string
-					delete_branch_on_merge: bool
-					git_url:                string
-					issue_comment_url:      string
-					statuses_url:           string
-					subscription_url:       string
-					deployments_url:        string
-					fork:                   bool
-					git_refs_url:           string
-					merges_url:             string
-					watchers_count:         int
-					assignees_url:          string
-					branches_url:           string
-					has_wiki:               bool
-					allow_update_branch:    bool
-					clone_url:              string
-					description:            string
-					open_issues:            int
-					stargazers_url:         string
-					trees_url:              string
-					allow_rebase_merge:     bool
-					archive_url:            string
-					blobs_url:              string
-					full_name:              string
-					has_pages:              bool
-					homepage:               _
-					disabled:               bool
-					downloads_url:          string
-					events_url:             string
-					forks_count:            int
-					hooks_url:              string
-					open_issues_count:      int
-					mirror_url:             _
-					ssh_url:                string
-					stargazers_count:       int
-					teams_url:              string
-					labels_url:             string
-					node_id:                string
-				}
-				sha: string
-				user: {
-					node_id:             string
-					organizations_url:   string
-					received_events_url: string
-					url:                 string
-					id:                  int
-					repos_url:           string
-					login:               string
-					subscriptions_url:   string
-					type:                string
-					avatar_url:          string
-					events_url:          string
-					gravatar_id:         string
-					html_url:            string
-					starred_url:         string
-					followers_url:       string
-					following_url:       string
-					gists_url:           string
-					site_admin:          bool
-				}
-			}
-			requested_reviewers: [...]
-			assignee:            _
-			comments:            int
-			html_url:            string
-			review_comments_url: string
-			state:               string
-			additions:           int
-			assignees: [...]
-			auto_merge:       _
-			merge_commit_sha: _
-			_links: {
-				html: {
-					href: string
-				}
-				issue: {
-					href: string
-				}
-				review_comment: {
-					href: string
-				}
-				review_comments: {
-					href: string
-				}
-				self: {
-					href: string
-				}
-				statuses: {
-					href: string
-				}
-				comments: {
-					href: string
-				}
-				commits: {
-					href: string
-				}
-			}
-			commits:               int
-			id:                    int
-			review_comment_url:    string
-			review_comments:       int
-			updated_at:            string
-			url:                   string
-			draft:                 bool
-			issue_url:             string
-			maintainer_can_modify: bool
-		}
-		repository: {
-			branches_url: string
-			html_url:     string
-			mirror_url:   _
-			size:         int
-			topics: [...]
-			forks_url:         string
-			has_issues:        bool
-			has_wiki:          bool
-			homepage:          _
-			stargazers_url:    string
-			trees_url:         string
-			updated_at:        string
-			compare_url:       string
-			downloads_url:     string
-			id:                int
-			git_url:           string
-			contributors_url:  string
-			disabled:          bool
-			git_commits_url:   string
-			keys_url:          string
-			open_issues:       int
-			open_issues_count: int
-			ssh_url:           string
-			subscribers_url:   string
-			collaborators_url: string
-			comments_url:      string
-			fork:              bool
-			git_tags_url:      string
-			node_id:           string
-			contents_url:      string
-			deployments_url:   string
-			notifications_url: string
-			owner: {
-				login:               string
-				node_id:             string
-				repos_url:           string
-				site_admin:          bool
-				url:                 string
-				followers_url:       string
-				gravatar_id:         string
-				html_url:            string
-				id:                  int
-				received_events_url: string
-				starred_url:         string
-				events_url:          string
-				type:                string
-				avatar_url:          string
-				following_url:       string
-				gists_url:           string
-				organizations_url:   string
-				subscriptions_url:   string
-			}
-			releases_url:      string
-			stargazers_count:  int
-			blobs_url:         string
-			issue_events_url:  string
-			tags_url:          string
-			default_branch:    string
-			events_url:        string
-			hooks_url:         string
-			statuses_url:      string
-			forks:             int
-			has_downloads:     bool
-			language:          string
-			subscription_url:  string
-			archived:          bool
-			created_at:        string
-			has_pages:         bool
-			merges_url:        string
-			pushed_at:         string
-			git_refs_url:      string
-			labels_url:        string
-			languages_url:     string
-			license:           _
-			milestones_url:    string
-			teams_url:         string
-			description:       string
-			private:           bool
-			pulls_url:         string
-			svn_url:           string
-			visibility:        string
-			forks_count:       int
-			full_name:         string
-			is_template:       bool
-			issues_url:        string
-			archive_url:       string
-			assignees_url:     string
-			commits_url:       string
-			has_projects:      bool
-			watchers:          int
-			allow_forking:     bool
-			clone_url:         string
-			issue_comment_url: string
-			name:              string
-			url:               string
-			watchers_count:    int
-		}
-		sender: {
-			events_url:          string
-			gists_url:           string
-			login:               string
-			url:                 string
-			followers_url:       string
-			following_url:       string
-			id:                  int
-			site_admin:          bool
-			subscriptions_url:   string
-			type:                string
-			html_url:            string
-			node_id:             string
-			avatar_url:          string
-			gravatar_id:         string
-			organizations_url:   string
-			received_events_url: string
-			repos_url:           string
-			starred_url:         string
-		}
-	}
-	ts:   int
-	name: string
+  name: string
+  data: {
+    action: string
+    number: int
+    organization: {
+      avatar_url:         string
+      description:        string
+      events_url:         string
+      hooks_url:          string
+      id:                 int
+      issues_url:         string
+      login:              string
+      members_url:        string
+      node_id:            string
+      public_members_url: string
+      repos_url:          string
+      url:                string
+    }
+    pull_request: {
+      _links: {
+        comments: {
+          href: string
+        }
+        commits: {
+          href: string
+        }
+        html: {
+          href: string
+        }
+        issue: {
+          href: string
+        }
+        review_comment: {
+          href: string
+        }
+        review_comments: {
+          href: string
+        }
+        self: {
+          href: string
+        }
+        statuses: {
+          href: string
+        }
+      }
+      active_lock_reason: _
+      additions:          int
+      assignee:           _
+      assignees: [..._]
+      author_association: string
+      auto_merge:         _
+      base: {
+        label: string
+        ref:   string
+        repo: {
+          allow_auto_merge:       bool
+          allow_forking:          bool
+          allow_merge_commit:     bool
+          allow_rebase_merge:     bool
+          allow_squash_merge:     bool
+          allow_update_branch:    bool
+          archive_url:            string
+          archived:               bool
+          assignees_url:          string
+          blobs_url:              string
+          branches_url:           string
+          clone_url:              string
+          collaborators_url:      string
+          comments_url:           string
+          commits_url:            string
+          compare_url:            string
+          contents_url:           string
+          contributors_url:       string
+          created_at:             string
+          default_branch:         string
+          delete_branch_on_merge: bool
+          deployments_url:        string
+          description:            string
+          disabled:               bool
+          downloads_url:          string
+          events_url:             string
+          fork:                   bool
+          forks:                  int
+          forks_count:            int
+          forks_url:              string
+          full_name:              string
+          git_commits_url:        string
+          git_refs_url:           string
+          git_tags_url:           string
+          git_url:                string
+          has_downloads:          bool
+          has_issues:             bool
+          has_pages:              bool
+          has_projects:           bool
+          has_wiki:               bool
+          homepage:               _
+          hooks_url:              string
+          html_url:               string
+          id:                     int
+          is_template:            bool
+          issue_comment_url:      string
+          issue_events_url:       string
+          issues_url:             string
+          keys_url:               string
+          labels_url:             string
+          language:               string
+          languages_url:          string
+          license:                _
+          merges_url:             string
+          milestones_url:         string
+          mirror_url:             _
+          name:                   string
+          node_id:                string
+          notifications_url:      string
+          open_issues:            int
+          open_issues_count:      int
+          owner: {
+            avatar_url:          string
+            events_url:          string
+            followers_url:       string
+            following_url:       string
+            gists_url:           string
+            gravatar_id:         string
+            html_url:            string
+            id:                  int
+            login:               string
+            node_id:             string
+            organizations_url:   string
+            received_events_url: string
+            repos_url:           string
+            site_admin:          bool
+            starred_url:         string
+            subscriptions_url:   string
+            type:                string
+            url:                 string
+          }
+          private:          bool
+          pulls_url:        string
+          pushed_at:        string
+          releases_url:     string
+          size:             int
+          ssh_url:          string
+          stargazers_count: int
+          stargazers_url:   string
+          statuses_url:     string
+          subscribers_url:  string
+          subscription_url: string
+          svn_url:          string
+          tags_url:         string
+          teams_url:        string
+          topics: [..._]
+          trees_url:      string
+          updated_at:     string
+          url:            string
+          visibility:     string
+          watchers:       int
+          watchers_count: int
+        }
+        sha: string
+        user: {
+          avatar_url:          string
+          events_url:          string
+          followers_url:       string
+          following_url:       string
+          gists_url:           string
+          gravatar_id:         string
+          html_url:            string
+          id:                  int
+          login:               string
+          node_id:             string
+          organizations_url:   string
+          received_events_url: string
+          repos_url:           string
+          site_admin:          bool
+          starred_url:         string
+          subscriptions_url:   string
+          type:                string
+          url:                 string
+        }
+      }
+      body:          string
+      changed_files: int
+      closed_at:     _
+      comments:      int
+      comments_url:  string
+      commits:       int
+      commits_url:   string
+      created_at:    string
+      deletions:     int
+      diff_url:      string
+      draft:         bool
+      head: {
+        label: string
+        ref:   string
+        repo: {
+          allow_auto_merge:       bool
+          allow_forking:          bool
+          allow_merge_commit:     bool
+          allow_rebase_merge:     bool
+          allow_squash_merge:     bool
+          allow_update_branch:    bool
+          archive_url:            string
+          archived:               bool
+          assignees_url:          string
+          blobs_url:              string
+          branches_url:           string
+          clone_url:              string
+          collaborators_url:      string
+          comments_url:           string
+          commits_url:            string
+          compare_url:            string
+          contents_url:           string
+          contributors_url:       string
+          created_at:             string
+          default_branch:         string
+          delete_branch_on_merge: bool
+          deployments_url:        string
+          description:            string
+          disabled:               bool
+          downloads_url:          string
+          events_url:             string
+          fork:                   bool
+          forks:                  int
+          forks_count:            int
+          forks_url:              string
+          full_name:              string
+          git_commits_url:        string
+          git_refs_url:           string
+          git_tags_url:           string
+          git_url:                string
+          has_downloads:          bool
+          has_issues:             bool
+          has_pages:              bool
+          has_projects:           bool
+          has_wiki:               bool
+          homepage:               _
+          hooks_url:              string
+          html_url:               string
+          id:                     int
+          is_template:            bool
+          issue_comment_url:      string
+          issue_events_url:       string
+          issues_url:             string
+          keys_url:               string
+          labels_url:             string
+          language:               string
+          languages_url:          string
+          license:                _
+          merges_url:             string
+          milestones_url:         string
+          mirror_url:             _
+          name:                   string
+          node_id:                string
+          notifications_url:      string
+          open_issues:            int
+          open_issues_count:      int
+          owner: {
+            avatar_url:          string
+            events_url:          string
+            followers_url:       string
+            following_url:       string
+            gists_url:           string
+            gravatar_id:         string
+            html_url:            string
+            id:                  int
+            login:               string
+            node_id:             string
+            organizations_url:   string
+            received_events_url: string
+            repos_url:           string
+            site_admin:          bool
+            starred_url:         string
+            subscriptions_url:   string
+            type:                string
+            url:                 string
+          }
+          private:          bool
+          pulls_url:        string
+          pushed_at:        string
+          releases_url:     string
+          size:             int
+          ssh_url:          string
+          stargazers_count: int
+          stargazers_url:   string
+          statuses_url:     string
+          subscribers_url:  string
+          subscription_url: string
+          svn_url:          string
+          tags_url:         string
+          teams_url:        string
+          topics: [..._]
+          trees_url:      string
+          updated_at:     string
+          url:            string
+          visibility:     string
+          watchers:       int
+          watchers_count: int
+        }
+        sha: string
+        user: {
+          avatar_url:          string
+          events_url:          string
+          followers_url:       string
+          following_url:       string
+          gists_url:           string
+          gravatar_id:         string
+          html_url:            string
+          id:                  int
+          login:               string
+          node_id:             string
+          organizations_url:   string
+          received_events_url: string
+          repos_url:           string
+          site_admin:          bool
+          starred_url:         string
+          subscriptions_url:   string
+          type:                string
+          url:                 string
+        }
+      }
+      html_url:  string
+      id:        int
+      issue_url: string
+      labels: [..._]
+      locked:                bool
+      maintainer_can_modify: bool
+      merge_commit_sha:      _
+      mergeable:             _
+      mergeable_state:       string
+      merged:                bool
+      merged_at:             _
+      merged_by:             _
+      milestone:             _
+      node_id:               string
+      number:                int
+      patch_url:             string
+      rebaseable:            _
+      requested_reviewers: [..._]
+      requested_teams: [..._]
+      review_comment_url:  string
+      review_comments:     int
+      review_comments_url: string
+      state:               string
+      statuses_url:        string
+      title:               string
+      updated_at:          string
+      url:                 string
+      user: {
+        avatar_url:          string
+        events_url:          string
+        followers_url:       string
+        following_url:       string
+        gists_url:           string
+        gravatar_id:         string
+        html_url:            string
+        id:                  int
+        login:               string
+        node_id:             string
+        organizations_url:   string
+        received_events_url: string
+        repos_url:           string
+        site_admin:          bool
+        starred_url:         string
+        subscriptions_url:   string
+        type:                string
+        url:                 string
+      }
+    }
+    repository: {
+      allow_forking:     bool
+      archive_url:       string
+      archived:          bool
+      assignees_url:     string
+      blobs_url:         string
+      branches_url:      string
+      clone_url:         string
+      collaborators_url: string
+      comments_url:      string
+      commits_url:       string
+      compare_url:       string
+      contents_url:      string
+      contributors_url:  string
+      created_at:        string
+      default_branch:    string
+      deployments_url:   string
+      description:       string
+      disabled:          bool
+      downloads_url:     string
+      events_url:        string
+      fork:              bool
+      forks:             int
+      forks_count:       int
+      forks_url:         string
+      full_name:         string
+      git_commits_url:   string
+      git_refs_url:      string
+      git_tags_url:      string
+      git_url:           string
+      has_downloads:     bool
+      has_issues:        bool
+      has_pages:         bool
+      has_projects:      bool
+      has_wiki:          bool
+      homepage:          _
+      hooks_url:         string
+      html_url:          string
+      id:                int
+      is_template:       bool
+      issue_comment_url: string
+      issue_events_url:  string
+      issues_url:        string
+      keys_url:          string
+      labels_url:        string
+      language:          string
+      languages_url:     string
+      license:           _
+      merges_url:        string
+      milestones_url:    string
+      mirror_url:        _
+      name:              string
+      node_id:           string
+      notifications_url: string
+      open_issues:       int
+      open_issues_count: int
+      owner: {
+        avatar_url:          string
+        events_url:          string
+        followers_url:       string
+        following_url:       string
+        gists_url:           string
+        gravatar_id:         string
+        html_url:            string
+        id:                  int
+        login:               string
+        node_id:             string
+        organizations_url:   string
+        received_events_url: string
+        repos_url:           string
+        site_admin:          bool
+        starred_url:         string
+        subscriptions_url:   string
+        type:                string
+        url:                 string
+      }
+      private:          bool
+      pulls_url:        string
+      pushed_at:        string
+      releases_url:     string
+      size:             int
+      ssh_url:          string
+      stargazers_count: int
+      stargazers_url:   string
+      statuses_url:     string
+      subscribers_url:  string
+      subscription_url: string
+      svn_url:          string
+      tags_url:         string
+      teams_url:        string
+      topics: [..._]
+      trees_url:      string
+      updated_at:     string
+      url:            string
+      visibility:     string
+      watchers:       int
+      watchers_count: int
+    }
+    sender: {
+      avatar_url:          string
+      events_url:          string
+      followers_url:       string
+      following_url:       string
+      gists_url:           string
+      gravatar_id:         string
+      html_url:            string
+      id:                  int
+      login:               string
+      node_id:             string
+      organizations_url:   string
+      received_events_url: string
+      repos_url:           string
+      site_admin:          bool
+      starred_url:         string
+      subscriptions_url:   string
+      type:                string
+      url:                 string
+    }
+  }
+  ts: int
 }
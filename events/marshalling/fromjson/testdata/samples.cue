@@ -0,0 +1,15 @@
+{
+  name: string
+  data: {
+    id:    int
+    email: string | null
+    tags?: [...string]
+    plan?: string
+    orgs?: [...{
+      id: string
+    } | {
+      id:   string
+      role: string
+    }]
+  }
+}
@@ -0,0 +1,134 @@
+package golang
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	"github.com/inngest/event-schemas/events/marshalling"
+)
+
+var (
+	_ marshalling.Expr = TypeDecl{}
+	_ marshalling.Expr = ConstDecl{}
+	_ marshalling.Expr = Struct{}
+	_ marshalling.Expr = Field{}
+)
+
+// Lit represents literal text, such as newlines, comments, spaces, etc.
+type Lit struct {
+	Value string
+}
+
+func (l Lit) String() string { return l.Value }
+
+// TypeDecl represents a named type declaration:
+//
+//	type Name Type
+type TypeDecl struct {
+	Name string
+	// Type is the underlying type of the declaration, eg. a Struct or
+	// a basic type such as "string".
+	Type marshalling.Expr
+}
+
+func (t TypeDecl) String() string {
+	return gofmt(fmt.Sprintf("type %s %s", t.Name, t.Type))
+}
+
+// Struct represents a struct type containing the given fields.
+type Struct struct {
+	Fields []Field
+}
+
+func (s Struct) String() string {
+	if len(s.Fields) == 0 {
+		return "struct{}"
+	}
+
+	str := strings.Builder{}
+	_, _ = str.WriteString("struct {\n")
+	for _, f := range s.Fields {
+		_, _ = str.WriteString(f.String())
+		_, _ = str.WriteString("\n")
+	}
+	_, _ = str.WriteString("}")
+	return str.String()
+}
+
+// Field represents a single struct field, with a JSON tag mapping the field
+// to its original key.
+type Field struct {
+	// Name is the exported Go name of the field.
+	Name string
+	// Key is the JSON key for the field.
+	Key string
+	// Type is the Go type of the field.
+	Type string
+	// Optional adds "omitempty" to the field's JSON tag.
+	Optional bool
+}
+
+func (f Field) String() string {
+	tag := f.Key
+	if f.Optional {
+		tag += ",omitempty"
+	}
+	return fmt.Sprintf("%s %s `json:%s`", f.Name, f.Type, strconv.Quote(tag))
+}
+
+// ConstDecl represents a block of typed constants, used to enumerate
+// the members of an enum:
+//
+//	const (
+//		ActionPush Action = "push"
+//	)
+type ConstDecl struct {
+	// Type is the named type for each constant.  If empty, the constants
+	// are untyped.
+	Type   string
+	Consts []Const
+}
+
+func (c ConstDecl) String() string {
+	str := strings.Builder{}
+	_, _ = str.WriteString("const (\n")
+	for _, item := range c.Consts {
+		if c.Type == "" {
+			_, _ = str.WriteString(fmt.Sprintf("%s = %s\n", item.Name, literal(item.Value)))
+			continue
+		}
+		_, _ = str.WriteString(fmt.Sprintf("%s %s = %s\n", item.Name, c.Type, literal(item.Value)))
+	}
+	_, _ = str.WriteString(")")
+	return gofmt(str.String())
+}
+
+// Const is a single named constant value.
+type Const struct {
+	Name  string
+	Value interface{}
+}
+
+// literal returns the Go literal for a scalar value.
+func literal(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return strconv.Quote(t)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// gofmt formats the given declaration, returning the input as-is if the
+// declaration can't be formatted.
+func gofmt(src string) string {
+	byt, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return strings.TrimSpace(string(byt))
+}
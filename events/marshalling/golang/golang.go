@@ -0,0 +1,382 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/events/marshalling"
+)
+
+const (
+	typeAny = "interface{}"
+)
+
+var (
+	// initialisms are rendered in upper case when generating Go identifiers,
+	// following Go's naming conventions (eg. "user_id" becomes "UserID").
+	initialisms = map[string]bool{
+		"api": true, "ascii": true, "cpu": true, "css": true, "dns": true,
+		"eof": true, "guid": true, "html": true, "http": true, "https": true,
+		"id": true, "ip": true, "json": true, "sql": true, "ssh": true,
+		"tls": true, "ttl": true, "ui": true, "uid": true, "uri": true,
+		"url": true, "utf8": true, "uuid": true, "xml": true,
+	}
+)
+
+func MarshalString(cuestr string) (string, error) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", cuestr)
+	if err != nil {
+		return "", fmt.Errorf("error generating inst: %w", err)
+	}
+	return MarshalCueValue(inst.Value())
+}
+
+// MarshalCueValue returns Go type declarations given a cue value.
+func MarshalCueValue(v cue.Value) (string, error) {
+	return marshalling.Marshal(context.Background(), v, generator{})
+}
+
+type generator struct{}
+
+func (g generator) AST(ctx context.Context, ast []marshalling.ParsedAST) ([]marshalling.Expr, error) {
+	return GenerateExprs(ctx, ast)
+}
+
+// GenerateExprs generates a Go declaration for each top-level identifier in
+// the parsed AST.  Nested structs and enums are hoisted into their own named
+// types, prefixed with the name of their parent (eg. the struct in `#Event:
+// { data: {...} }` becomes EventData).
+func GenerateExprs(ctx context.Context, ast []marshalling.ParsedAST) ([]marshalling.Expr, error) {
+	s := &scope{
+		names: map[string]bool{},
+		refs:  map[string]string{},
+	}
+
+	// Name every top-level identifier up front, allowing fields to refer
+	// to other definitions by name.
+	for _, item := range ast {
+		s.refs[item.Name()] = s.name(exportedName(item.Name()))
+	}
+
+	for _, item := range ast {
+		if err := s.declare(item); err != nil {
+			return nil, err
+		}
+	}
+
+	exprs := []marshalling.Expr{}
+	for n, decl := range s.decls {
+		if n > 0 {
+			exprs = append(exprs, Lit{Value: "\n\n"})
+		}
+		exprs = append(exprs, decl)
+	}
+	return exprs, nil
+}
+
+// scope records every declaration generated for a set of parsed ASTs.
+type scope struct {
+	decls []marshalling.Expr
+	names map[string]bool
+	// refs maps each top-level cue label to its Go type name.
+	refs map[string]string
+}
+
+// declare adds a top-level declaration for the given item.
+func (s *scope) declare(item marshalling.ParsedAST) error {
+	name := s.refs[item.Name()]
+
+	switch parsed := item.(type) {
+	case *marshalling.ParsedStruct:
+		_, err := s.structType(name, parsed)
+		return err
+	case *marshalling.ParsedEnum:
+		typ, err := s.enumType(name, parsed)
+		if err != nil {
+			return err
+		}
+		if typ != name {
+			// This enum couldn't be represented as a set of typed
+			// constants.  Alias the underlying type instead.
+			s.decls = append(s.decls, TypeDecl{Name: name, Type: Lit{Value: typ}})
+		}
+		return nil
+	case *marshalling.ParsedScalar:
+		s.decls = append(s.decls, ConstDecl{
+			Consts: []Const{{Name: name, Value: parsed.Value}},
+		})
+		return nil
+	default:
+		typ, err := s.typeOf(name, item)
+		if err != nil {
+			return err
+		}
+		s.decls = append(s.decls, TypeDecl{Name: name, Type: Lit{Value: typ}})
+		return nil
+	}
+}
+
+// typeOf returns the Go type for the given parsed AST.  Structs and enums
+// are declared as named types using the given name.
+func (s *scope) typeOf(name string, item marshalling.ParsedAST) (string, error) {
+	switch parsed := item.(type) {
+	case *marshalling.ParsedStructField:
+		return s.typeOf(name, parsed.ParsedAST)
+	case *marshalling.ParsedStruct:
+		if len(parsed.Members) == 0 {
+			return "map[string]interface{}", nil
+		}
+		return s.structType(s.name(name), parsed)
+	case *marshalling.ParsedEnum:
+		return s.enumType(s.name(name), parsed)
+	case *marshalling.ParsedArray:
+		elems := make([]string, len(parsed.Members))
+		for n, m := range parsed.Members {
+			typ, err := s.typeOf(name+"Item", m)
+			if err != nil {
+				return "", err
+			}
+			elems[n] = typ
+		}
+		if len(elems) == 0 {
+			return "[]" + typeAny, nil
+		}
+		return "[]" + union(elems, false), nil
+	case *marshalling.ParsedIdent:
+		if ref, ok := s.refs[parsed.Ident.Name]; ok {
+			return ref, nil
+		}
+		return identToGo(parsed.Ident.Name), nil
+	case *marshalling.ParsedScalar:
+		return scalarToGo(parsed.Value), nil
+	}
+	return "", fmt.Errorf("unknown parsed ast: %T", item)
+}
+
+// structType declares a named struct type, returning the struct's name.
+func (s *scope) structType(name string, p *marshalling.ParsedStruct) (string, error) {
+	// Reserve the declaration so that the parent struct is always
+	// declared before any nested types.
+	idx := len(s.decls)
+	s.decls = append(s.decls, nil)
+
+	typ := Struct{Fields: []Field{}}
+	seen := map[string]bool{}
+	for _, member := range p.Members {
+		base := exportedName(member.Name())
+		if base == "" {
+			base = "Field"
+		}
+		field := base
+		for i := 2; seen[field]; i++ {
+			field = fmt.Sprintf("%s%d", base, i)
+		}
+		seen[field] = true
+		ftype, err := s.typeOf(name+field, member.ParsedAST)
+		if err != nil {
+			return "", err
+		}
+		if member.Optional && !nillable(ftype) {
+			ftype = "*" + ftype
+		}
+		typ.Fields = append(typ.Fields, Field{
+			Name:     field,
+			Key:      member.Name(),
+			Type:     ftype,
+			Optional: member.Optional,
+		})
+	}
+
+	s.decls[idx] = TypeDecl{Name: name, Type: typ}
+	return name, nil
+}
+
+// enumType declares a named type with typed constants for each enum member,
+// if the members are all strings or all integers.  Otherwise, this returns the
+// type that satisfies every member of the enum, eg. `string | null` returns
+// "*string".
+func (s *scope) enumType(name string, p *marshalling.ParsedEnum) (string, error) {
+	var kind string
+	for _, m := range p.Members {
+		scalar, ok := m.(*marshalling.ParsedScalar)
+		if !ok {
+			kind = ""
+			break
+		}
+		typ := scalarToGo(scalar.Value)
+		if kind != "" && typ != kind {
+			kind = ""
+			break
+		}
+		kind = typ
+	}
+
+	if kind != "string" && kind != "int" {
+		types := []string{}
+		nullable := false
+		for n, m := range p.Members {
+			if scalar, ok := m.(*marshalling.ParsedScalar); ok && scalar.Value == nil {
+				nullable = true
+				continue
+			}
+			typ, err := s.typeOf(fmt.Sprintf("%s%d", name, n), m)
+			if err != nil {
+				return "", err
+			}
+			types = append(types, typ)
+		}
+		return union(types, nullable), nil
+	}
+
+	decl := ConstDecl{Type: name}
+	seen := map[string]bool{}
+	for _, m := range p.Members {
+		value := m.(*marshalling.ParsedScalar).Value
+		suffix := camelCase(fmt.Sprintf("%v", value))
+		if suffix == "" {
+			suffix = "Empty"
+		}
+		cname := name + suffix
+		for i := 2; seen[cname]; i++ {
+			cname = fmt.Sprintf("%s%s%d", name, suffix, i)
+		}
+		seen[cname] = true
+		decl.Consts = append(decl.Consts, Const{Name: cname, Value: value})
+	}
+
+	s.decls = append(s.decls, TypeDecl{Name: name, Type: Lit{Value: kind}}, decl)
+	return name, nil
+}
+
+// name returns a unique type name within the scope.
+func (s *scope) name(name string) string {
+	if name == "" {
+		name = "Type"
+	}
+	unique := name
+	for i := 2; s.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	s.names[unique] = true
+	return unique
+}
+
+// union returns a single Go type which can hold every given type.
+func union(types []string, nullable bool) string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, t := range types {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		unique = append(unique, t)
+	}
+
+	// Integers and floats can both be held within a float64.
+	if len(unique) == 2 && seen["int"] && seen["float64"] {
+		unique = []string{"float64"}
+	}
+
+	if len(unique) != 1 {
+		return typeAny
+	}
+	if nullable && !nillable(unique[0]) {
+		return "*" + unique[0]
+	}
+	return unique[0]
+}
+
+// nillable returns whether the zero value of the given type is nil.
+func nillable(typ string) bool {
+	return typ == typeAny ||
+		strings.HasPrefix(typ, "*") ||
+		strings.HasPrefix(typ, "[]") ||
+		strings.HasPrefix(typ, "map[")
+}
+
+// identToGo returns Go type names from a given cue type name.
+func identToGo(name string) string {
+	switch name {
+	case "string":
+		return "string"
+	case "bool":
+		return "bool"
+	case "int":
+		return "int"
+	case "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "rune":
+		return name
+	case "float", "number":
+		return "float64"
+	case "bytes":
+		return "[]byte"
+	case "[...]":
+		return "[]" + typeAny
+	case "{...}":
+		return "map[string]interface{}"
+	default:
+		return typeAny
+	}
+}
+
+// scalarToGo returns the Go type for a concrete scalar value.
+func scalarToGo(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int, int64:
+		return "int"
+	case float64:
+		return "float64"
+	default:
+		return typeAny
+	}
+}
+
+// exportedName returns an exported Go identifier for the given cue label,
+// eg. "created_at" becomes "CreatedAt" and "#user_id" becomes "UserID".
+func exportedName(label string) string {
+	// Signed labels, such as GitHub's "+1" and "-1" reactions, would otherwise
+	// produce the same identifier.
+	switch {
+	case strings.HasPrefix(label, "+"):
+		label = "plus_" + label[1:]
+	case strings.HasPrefix(label, "-"):
+		label = "minus_" + label[1:]
+	}
+
+	name := camelCase(label)
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// camelCase joins each alphanumeric part of the given string in camel case,
+// upper-casing any initialisms.
+func camelCase(label string) string {
+	parts := strings.FieldsFunc(label, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	str := strings.Builder{}
+	for _, p := range parts {
+		if initialisms[strings.ToLower(p)] {
+			_, _ = str.WriteString(strings.ToUpper(p))
+			continue
+		}
+		runes := []rune(p)
+		runes[0] = unicode.ToUpper(runes[0])
+		_, _ = str.WriteString(string(runes))
+	}
+
+	return str.String()
+}
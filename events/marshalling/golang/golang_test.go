@@ -0,0 +1,52 @@
+package golang
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoGeneration(t *testing.T) {
+	entries, err := os.ReadDir("./testdata")
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".cue") {
+			continue
+		}
+
+		name := e.Name()
+		t.Run(name, func(t *testing.T) {
+			contents, err := ioutil.ReadFile(path.Join("./testdata", name))
+			require.NoError(t, err)
+
+			expected, err := ioutil.ReadFile(path.Join("./testdata", strings.ReplaceAll(name, ".cue", ".go.txt")))
+			require.NoError(t, err)
+
+			actual, err := MarshalString(string(contents))
+			require.NoError(t, err)
+			require.EqualValues(t, strings.TrimSpace(string(expected)), actual)
+		})
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"name":          "Name",
+		"#Event":        "Event",
+		"user_id":       "UserID",
+		"html_url":      "HTMLURL",
+		"createdAt":     "CreatedAt",
+		"charge.failed": "ChargeFailed",
+		"2fa":           "X2fa",
+		"+1":            "Plus1",
+		"-1":            "Minus1",
+	}
+	for input, expected := range tests {
+		require.Equal(t, expected, exportedName(input), input)
+	}
+}
@@ -0,0 +1,14 @@
+#User: {
+	id:        uint8
+	email?:    string
+	avatar:    string | null
+	tags?:     [...string]
+	metadata?: {...}
+	address?: {
+		city:     string
+		zip_code: string
+	}
+	labels: [...]
+}
+
+#Level: 1 | 2 | 3
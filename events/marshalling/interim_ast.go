@@ -90,8 +90,9 @@ type ParsedIdent struct {
 	// Default represents the default value, if any.
 	Default interface{}
 
-	// TODO: Expose constraints.
-	constraints []interface{}
+	// Constraints lists the constraints placed on the ident, eg. the bounds
+	// within `uint & <= 10`.
+	Constraints []Constraint
 }
 
 func (ParsedIdent) Kind() ParsedKind { return KindIdent }
@@ -119,3 +120,63 @@ func (p ParsedScalar) Name() string { return p.name }
 func (*ParsedScalar) SetDefault(to interface{}) {
 	panic("impossible on scalars")
 }
+
+// ConstraintKind represents the kind of constraint placed on an ident.
+type ConstraintKind int
+
+const (
+	// ConstraintMin is a lower bound on a number, eg. `>= 5` or `> 5`.
+	ConstraintMin ConstraintKind = iota
+	// ConstraintMax is an upper bound on a number, eg. `<= 10` or `< 10`.
+	ConstraintMax
+	// ConstraintRegex requires a string to match the regular expression
+	// within Value, eg. `=~"^[a-z]+$"`.
+	ConstraintRegex
+	// ConstraintNotRegex requires a string to not match the regular
+	// expression within Value, eg. `!~"^[a-z]+$"`.
+	ConstraintNotRegex
+	// ConstraintMinLength is the minimum number of runes within a string,
+	// eg. `strings.MinRunes(3)`.
+	ConstraintMinLength
+	// ConstraintMaxLength is the maximum number of runes within a string,
+	// eg. `strings.MaxRunes(10)`.
+	ConstraintMaxLength
+	// ConstraintWidth is the width in bits of a sized integer type, eg. 8 for
+	// `uint8`.  The ident's name records whether the integer is signed.
+	ConstraintWidth
+	// ConstraintNotEqual excludes a single value, eg. `!= "foo"`.
+	ConstraintNotEqual
+)
+
+func (c ConstraintKind) String() string {
+	switch c {
+	case ConstraintMin:
+		return "min"
+	case ConstraintMax:
+		return "max"
+	case ConstraintRegex:
+		return "regex"
+	case ConstraintNotRegex:
+		return "not regex"
+	case ConstraintMinLength:
+		return "min length"
+	case ConstraintMaxLength:
+		return "max length"
+	case ConstraintWidth:
+		return "width"
+	case ConstraintNotEqual:
+		return "not equal"
+	}
+	return ""
+}
+
+// Constraint represents a single constraint on a ParsedIdent.
+type Constraint struct {
+	Kind ConstraintKind
+	// Value is the value of the constraint, eg. the bound for ConstraintMin,
+	// the pattern for ConstraintRegex or the number of bits for
+	// ConstraintWidth.
+	Value interface{}
+	// Exclusive is true if the bound excludes the value itself, eg. `< 10`.
+	Exclusive bool
+}
@@ -0,0 +1,209 @@
+// Package orderedjson decodes JSON objects which retain the order of their
+// keys, eg. so that types inferred from JSON have fields in a deterministic
+// order, or so that JSON schemas are rewritten without reordering keywords.
+package orderedjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Object is a JSON object which retains the order of its keys.
+type Object struct {
+	// Keys lists the object's keys in order.
+	Keys []string
+	// Values stores the value of each key.
+	Values map[string]interface{}
+}
+
+func NewObject() *Object {
+	return &Object{Values: map[string]interface{}{}}
+}
+
+// Get returns the value for the given key.
+func (o *Object) Get(key string) (interface{}, bool) {
+	v, ok := o.Values[key]
+	return v, ok
+}
+
+// Has returns whether the object contains the given key.
+func (o *Object) Has(key string) bool {
+	_, ok := o.Values[key]
+	return ok
+}
+
+// Set sets the value for the given key.  Keys retain the position in which
+// they were first set.
+func (o *Object) Set(key string, value interface{}) {
+	if _, ok := o.Values[key]; !ok {
+		o.Keys = append(o.Keys, key)
+	}
+	o.Values[key] = value
+}
+
+// Rename renames a key, retaining the key's position.
+func (o *Object) Rename(from, to string) {
+	for n, k := range o.Keys {
+		if k == from {
+			o.Keys[n] = to
+		}
+	}
+	o.Values[to] = o.Values[from]
+	delete(o.Values, from)
+}
+
+// Delete removes the given key.
+func (o *Object) Delete(key string) {
+	for n, k := range o.Keys {
+		if k == key {
+			o.Keys = append(o.Keys[:n], o.Keys[n+1:]...)
+			break
+		}
+	}
+	delete(o.Values, key)
+}
+
+// MarshalJSON encodes the object with its keys in order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for n, k := range o.Keys {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(o.Values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// FromValue converts each map within a value decoded by json.Unmarshal to an
+// object with keys sorted alphabetically, as maps have no order.
+func FromValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		obj := NewObject()
+		for k, item := range t {
+			obj.Set(k, FromValue(item))
+		}
+		sort.Strings(obj.Keys)
+		return obj
+	case []interface{}:
+		list := make([]interface{}, len(t))
+		for n, item := range t {
+			list[n] = FromValue(item)
+		}
+		return list
+	}
+	return v
+}
+
+// SortKeys sorts the keys of every object within the value alphabetically.
+func SortKeys(v interface{}) {
+	switch t := v.(type) {
+	case *Object:
+		sort.Strings(t.Keys)
+		for _, item := range t.Values {
+			SortKeys(item)
+		}
+	case []interface{}:
+		for _, item := range t {
+			SortKeys(item)
+		}
+	}
+}
+
+// Decode decodes the next JSON value from the decoder, using the token stream
+// so that objects retain their source key order.  Objects are decoded as
+// *Object, and every other value is decoded as with json.Unmarshal into an
+// interface{}, respecting the decoder's UseNumber setting.
+//
+// This returns io.EOF if there are no more values within the stream.
+func Decode(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	return decodeToken(dec, tok)
+}
+
+// decodeNested decodes a value within an object or array, where the stream
+// ending is always an error.
+func decodeNested(dec *json.Decoder) (interface{}, error) {
+	tok, err := nestedToken(dec)
+	if err != nil {
+		return nil, err
+	}
+	return decodeToken(dec, tok)
+}
+
+// nestedToken returns the next token within an object or array.
+func nestedToken(dec *json.Decoder) (json.Token, error) {
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return tok, err
+}
+
+func decodeToken(dec *json.Decoder, tok json.Token) (interface{}, error) {
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		// This is a scalar: a string, number, bool or nil.
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := NewObject()
+		for dec.More() {
+			tok, err := nestedToken(dec)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid object key: %v", tok)
+			}
+			val, err := decodeNested(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(key, val)
+		}
+		// Consume the closing delimiter.
+		if _, err := nestedToken(dec); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case '[':
+		list := []interface{}{}
+		for dec.More() {
+			val, err := decodeNested(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+		if _, err := nestedToken(dec); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+
+	return nil, fmt.Errorf("unexpected delimiter: %s", delim)
+}
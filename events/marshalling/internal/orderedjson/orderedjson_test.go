@@ -0,0 +1,60 @@
+package orderedjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(`{"b": 1, "a": {"z": [true, null], "y": "s"}} {"c": 2}`)))
+	dec.UseNumber()
+
+	val, err := Decode(dec)
+	require.NoError(t, err)
+	obj, ok := val.(*Object)
+	require.True(t, ok)
+	require.Equal(t, []string{"b", "a"}, obj.Keys)
+	require.Equal(t, json.Number("1"), obj.Values["b"])
+
+	byt, err := json.Marshal(obj)
+	require.NoError(t, err)
+	require.Equal(t, `{"b":1,"a":{"z":[true,null],"y":"s"}}`, string(byt))
+
+	SortKeys(obj)
+	byt, err = json.Marshal(obj)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":{"y":"s","z":[true,null]},"b":1}`, string(byt))
+
+	_, err = Decode(dec)
+	require.NoError(t, err)
+	_, err = Decode(dec)
+	require.True(t, errors.Is(err, io.EOF))
+
+	_, err = Decode(json.NewDecoder(bytes.NewReader([]byte(`{"a": [1`))))
+	require.Error(t, err)
+}
+
+func TestObject(t *testing.T) {
+	obj := NewObject()
+	obj.Set("a", 1)
+	obj.Set("b", 2)
+	obj.Set("a", 3)
+	obj.Rename("a", "c")
+	obj.Delete("b")
+	obj.Set("d", FromValue(map[string]interface{}{"y": 1, "x": 2}))
+
+	require.True(t, obj.Has("c"))
+	require.False(t, obj.Has("a"))
+	v, ok := obj.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+
+	byt, err := json.Marshal(obj)
+	require.NoError(t, err)
+	require.Equal(t, `{"c":3,"d":{"x":2,"y":1}}`, string(byt))
+}
@@ -0,0 +1,248 @@
+package jsonschema
+
+import (
+	"strings"
+)
+
+// Dialect is the flavor of JSON schema generated.
+type Dialect int
+
+const (
+	// DialectOpenAPI generates OpenAPI 3.0 schema objects, which use
+	// "nullable", boolean exclusive bounds and single-value enums in place of
+	// "const".  This is the default, for compatibility.
+	DialectOpenAPI Dialect = iota
+	// Dialect202012 generates standalone JSON schema draft 2020-12 documents.
+	Dialect202012
+	// DialectDraft07 generates standalone JSON schema draft-07 documents, for
+	// tools such as AsyncAPI which don't support later drafts.
+	DialectDraft07
+)
+
+const (
+	// Draft202012 is the "$schema" of JSON schema draft 2020-12 documents.
+	Draft202012 = "https://json-schema.org/draft/2020-12/schema"
+	// Draft07 is the "$schema" of JSON schema draft-07 documents.
+	Draft07 = "http://json-schema.org/draft-07/schema#"
+
+	// draft07DefsPrefix prefixes references to draft-07 definitions.
+	draft07DefsPrefix = "#/definitions/"
+
+	// EventIDPrefix prefixes the "$id" of each event's schema.  IDs are URNs,
+	// as the schemas aren't published at a URL.
+	EventIDPrefix = "urn:inngest:event:"
+)
+
+// Options configures the JSON schema document generated by Convert.
+type Options struct {
+	// Dialect is the flavor of JSON schema to generate.
+	Dialect Dialect
+	// ID is the "$id" of the document, if any.  Use EventID to create an ID
+	// for an event.
+	ID string
+}
+
+// EventID returns the "$id" of the schema for the given event name and
+// version, eg. "urn:inngest:event:stripe/charge.failed", or
+// "urn:inngest:event:acme/signup:2" for a versioned event.
+func EventID(name, version string) string {
+	if version != "" {
+		return EventIDPrefix + name + ":" + version
+	}
+	return EventIDPrefix + name
+}
+
+// Convert converts a schema generated by MarshalDefinitions or MarshalCueValue
+// to the given dialect, returning a new schema.  Converting to draft 2020-12:
+//
+//   - adds "$schema" and "$id"
+//   - rewrites "nullable" to a "null" type, enum member or "oneOf"
+//   - rewrites single-value enums, ie. concrete scalars, to "const"
+//   - rewrites boolean "exclusiveMinimum" and "exclusiveMaximum" to numbers
+//   - rewrites OpenAPI component references to "$defs" references
+//
+// Converting to draft-07 converts to draft 2020-12, then rewrites "$defs" to
+// "definitions" and "prefixItems" to a list of "items".
+func Convert(schema map[string]interface{}, o Options) map[string]interface{} {
+	if o.Dialect == DialectOpenAPI {
+		return copyValue(schema).(map[string]interface{})
+	}
+
+	converted := toDraft202012(copyValue(schema).(map[string]interface{}))
+	doc := map[string]interface{}{"$schema": Draft202012}
+	if o.Dialect == DialectDraft07 {
+		converted = toDraft07(converted)
+		doc["$schema"] = Draft07
+	}
+	if o.ID != "" {
+		doc["$id"] = o.ID
+	}
+	for k, v := range converted {
+		doc[k] = v
+	}
+	return doc
+}
+
+// toDraft202012 converts an OpenAPI schema object to draft 2020-12 in place,
+// returning the converted schema.
+func toDraft202012(schema map[string]interface{}) map[string]interface{} {
+	for k, v := range schema {
+		switch k {
+		case "$ref":
+			if ref, ok := v.(string); ok {
+				schema[k] = strings.Replace(ref, componentsPrefix, defsPrefix, 1)
+			}
+		case "properties", "patternProperties", "$defs":
+			if m, ok := v.(map[string]interface{}); ok {
+				for name, s := range m {
+					if sub, ok := s.(map[string]interface{}); ok {
+						m[name] = toDraft202012(sub)
+					}
+				}
+			}
+		case "allOf", "anyOf", "oneOf":
+			if list, ok := v.([]interface{}); ok {
+				for n, s := range list {
+					if sub, ok := s.(map[string]interface{}); ok {
+						list[n] = toDraft202012(sub)
+					}
+				}
+			}
+		case "items", "additionalProperties", "not":
+			if sub, ok := v.(map[string]interface{}); ok {
+				schema[k] = toDraft202012(sub)
+			}
+		}
+	}
+
+	exclusive(schema, "exclusiveMinimum", "minimum")
+	exclusive(schema, "exclusiveMaximum", "maximum")
+
+	if example, ok := schema["example"]; ok {
+		delete(schema, "example")
+		schema["examples"] = []interface{}{example}
+	}
+
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		delete(schema, "nullable")
+		schema = allowNull(schema)
+	}
+	delete(schema, "nullable")
+
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) == 1 {
+		delete(schema, "enum")
+		schema["const"] = enum[0]
+	}
+	return schema
+}
+
+// toDraft07 converts a draft 2020-12 schema to draft-07 in place, returning
+// the converted schema.
+func toDraft07(schema map[string]interface{}) map[string]interface{} {
+	for k, v := range schema {
+		switch k {
+		case "$ref":
+			if ref, ok := v.(string); ok {
+				schema[k] = strings.Replace(ref, defsPrefix, draft07DefsPrefix, 1)
+			}
+		case "properties", "patternProperties", "$defs":
+			if m, ok := v.(map[string]interface{}); ok {
+				for name, s := range m {
+					if sub, ok := s.(map[string]interface{}); ok {
+						m[name] = toDraft07(sub)
+					}
+				}
+			}
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			if list, ok := v.([]interface{}); ok {
+				for n, s := range list {
+					if sub, ok := s.(map[string]interface{}); ok {
+						list[n] = toDraft07(sub)
+					}
+				}
+			}
+		case "items", "additionalProperties", "not":
+			if sub, ok := v.(map[string]interface{}); ok {
+				schema[k] = toDraft07(sub)
+			}
+		}
+	}
+
+	if defs, ok := schema["$defs"]; ok {
+		delete(schema, "$defs")
+		schema["definitions"] = defs
+	}
+	if prefix, ok := schema["prefixItems"]; ok {
+		// Draft-07 tuples list each item within "items", with any remaining
+		// items within "additionalItems".
+		delete(schema, "prefixItems")
+		if items, ok := schema["items"]; ok {
+			schema["additionalItems"] = items
+		}
+		schema["items"] = prefix
+	}
+	return schema
+}
+
+// exclusive rewrites an OpenAPI boolean exclusive bound, which modifies the
+// inclusive bound, to a numeric exclusive bound.
+func exclusive(schema map[string]interface{}, key, bound string) {
+	isExclusive, ok := schema[key].(bool)
+	if !ok {
+		return
+	}
+	delete(schema, key)
+	if isExclusive {
+		schema[key] = schema[bound]
+		delete(schema, bound)
+	}
+}
+
+// allowNull returns a schema which also allows null values.
+func allowNull(schema map[string]interface{}) map[string]interface{} {
+	for _, k := range []string{"$ref", "allOf", "anyOf", "oneOf", "not"} {
+		if _, ok := schema[k]; ok {
+			// Composed schemas can't add a type, so allow null via a
+			// disjunction.
+			return map[string]interface{}{
+				"oneOf": []interface{}{schema, map[string]interface{}{"type": "null"}},
+			}
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsNull(enum) {
+		schema["enum"] = append(enum, nil)
+	}
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []interface{}{typ, "null"}
+	}
+	return schema
+}
+
+func containsNull(list []interface{}) bool {
+	for _, v := range list {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// copyValue deep copies JSON maps and lists.
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			m[k] = copyValue(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(t))
+		for n, item := range t {
+			list[n] = copyValue(item)
+		}
+		return list
+	}
+	return v
+}
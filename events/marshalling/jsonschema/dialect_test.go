@@ -0,0 +1,148 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	input := `#event: {
+  name: "acme/user.created"
+  data: {
+    email:   string | null
+    plan:    "free" | "pro"
+    seats:   int | string
+    score:   >0 & <10
+    manager: #User | null
+    deleted: null
+  }
+}
+
+#User: {
+  login: string
+}`
+
+	schema, err := MarshalDefinitions(input, "event")
+	require.NoError(t, err)
+
+	// The OpenAPI dialect is unchanged.
+	require.Equal(t, schema, Convert(schema, Options{}))
+
+	actual := Convert(schema, Options{
+		Dialect: Dialect202012,
+		ID:      EventID("acme/user.created", ""),
+	})
+
+	expected := map[string]interface{}{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"$id":      "urn:inngest:event:acme/user.created",
+		"type":     "object",
+		"required": []interface{}{"name", "data"},
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":  "string",
+				"const": "acme/user.created",
+			},
+			"data": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"email", "plan", "seats", "score", "manager", "deleted"},
+				"properties": map[string]interface{}{
+					"email": map[string]interface{}{
+						"type": []interface{}{"string", "null"},
+					},
+					"plan": map[string]interface{}{
+						"type": "string",
+						"enum": []interface{}{"free", "pro"},
+					},
+					"seats": map[string]interface{}{
+						"oneOf": []interface{}{
+							map[string]interface{}{"type": "integer"},
+							map[string]interface{}{"type": "string"},
+						},
+					},
+					"score": map[string]interface{}{
+						"type":             "number",
+						"exclusiveMinimum": float64(0),
+						"exclusiveMaximum": float64(10),
+					},
+					"manager": map[string]interface{}{
+						"oneOf": []interface{}{
+							map[string]interface{}{
+								"type":  "object",
+								"allOf": []interface{}{map[string]interface{}{"$ref": "#/$defs/User"}},
+							},
+							map[string]interface{}{"type": "null"},
+						},
+					},
+					"deleted": map[string]interface{}{
+						"const": nil,
+					},
+				},
+			},
+		},
+		"$defs": map[string]interface{}{
+			"User": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"login"},
+				"properties": map[string]interface{}{
+					"login": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+	require.EqualValues(t, expected, actual)
+
+	// Converting doesn't modify the OpenAPI schema.
+	require.Equal(t, true, schema["properties"].(map[string]interface{})["data"].(map[string]interface{})["properties"].(map[string]interface{})["email"].(map[string]interface{})["nullable"])
+}
+
+func TestConvertDraft07(t *testing.T) {
+	input := `#event: {
+  name: "acme/user.created"
+  data: {
+    email:   string | null
+    manager: #User | null
+  }
+}
+
+#User: {
+  login: string
+}`
+
+	schema, err := MarshalDefinitions(input, "event")
+	require.NoError(t, err)
+
+	actual := Convert(schema, Options{Dialect: DialectDraft07})
+	require.Equal(t, "http://json-schema.org/draft-07/schema#", actual["$schema"])
+	require.NotContains(t, actual, "$defs")
+	require.Contains(t, actual["definitions"], "User")
+
+	data := actual["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"oneOf": []interface{}{
+			map[string]interface{}{
+				"type":  "object",
+				"allOf": []interface{}{map[string]interface{}{"$ref": "#/definitions/User"}},
+			},
+			map[string]interface{}{"type": "null"},
+		},
+	}, data["properties"].(map[string]interface{})["manager"])
+
+	// Tuples list each item within "items".
+	tuple := toDraft07(map[string]interface{}{
+		"type":        "array",
+		"prefixItems": []interface{}{map[string]interface{}{"type": "string"}, map[string]interface{}{"$ref": "#/$defs/User"}},
+		"items":       map[string]interface{}{"type": "integer"},
+	})
+	require.Equal(t, map[string]interface{}{
+		"type":            "array",
+		"items":           []interface{}{map[string]interface{}{"type": "string"}, map[string]interface{}{"$ref": "#/definitions/User"}},
+		"additionalItems": map[string]interface{}{"type": "integer"},
+	}, tuple)
+}
+
+func TestEventID(t *testing.T) {
+	require.Equal(t, "urn:inngest:event:stripe/charge.failed", EventID("stripe/charge.failed", ""))
+	require.Equal(t, "urn:inngest:event:acme/signup:2", EventID("acme/signup", "2"))
+}
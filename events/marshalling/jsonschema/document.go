@@ -0,0 +1,34 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/inngest/event-schemas/events/marshalling/internal/orderedjson"
+)
+
+// parseDocument parses a JSON schema document, which must be an object.  Cue
+// decodes some keywords, such as "items" and "additionalItems", in the order
+// in which they're defined, so objects retain the order of their keys and
+// schemas must be rewritten without reordering keys.
+func parseDocument(byt []byte) (*orderedjson.Object, error) {
+	dec := json.NewDecoder(bytes.NewReader(byt))
+	dec.UseNumber()
+
+	val, err := orderedjson.Decode(dec)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing JSON: unexpected data after schema")
+	}
+
+	obj, ok := val.(*orderedjson.Object)
+	if !ok {
+		return nil, fmt.Errorf("error parsing JSON: expected an object")
+	}
+	return obj, nil
+}
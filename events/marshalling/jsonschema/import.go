@@ -0,0 +1,583 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/ast/astutil"
+	"cuelang.org/go/cue/format"
+	cuejson "cuelang.org/go/encoding/json"
+	"cuelang.org/go/encoding/jsonschema"
+	"github.com/inngest/event-schemas/events/marshalling/internal/orderedjson"
+	"github.com/inngest/event-schemas/pkg/formats"
+
+	// Register cue's builtin packages, such as "strings", as schemas with
+	// length constraints import builtin validators.
+	_ "cuelang.org/go/pkg"
+)
+
+// Resolver loads the JSON schema document with the given URI.  URIs are
+// resolved against the "$id" of the referencing document, or the URI the
+// referencing document was loaded from.
+type Resolver interface {
+	Resolve(uri string) ([]byte, error)
+}
+
+// ResolverFunc is a function which implements Resolver, eg. to fetch schemas
+// over HTTP.
+type ResolverFunc func(uri string) ([]byte, error)
+
+func (f ResolverFunc) Resolve(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+// Documents is a set of JSON schema documents keyed by URI.  A document may
+// also be resolved by its "$id".
+type Documents map[string][]byte
+
+func (d Documents) Resolve(uri string) ([]byte, error) {
+	if byt, ok := d[uri]; ok {
+		return byt, nil
+	}
+
+	// Sort the URIs so that duplicate IDs resolve deterministically.
+	uris := make([]string, 0, len(d))
+	for k := range d {
+		uris = append(uris, k)
+	}
+	sort.Strings(uris)
+	for _, k := range uris {
+		doc := struct {
+			ID string `json:"$id"`
+		}{}
+		if err := json.Unmarshal(d[k], &doc); err == nil && doc.ID != "" && trimFragment(doc.ID) == uri {
+			return d[k], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown schema document: %s", uri)
+}
+
+// ReadDocuments reads every JSON file within the filesystem, keyed by path,
+// eg. for a directory of schemas which reference each other by relative paths
+// or by "$id".
+func ReadDocuments(fsys fs.FS) (Documents, error) {
+	docs := Documents{}
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".json" {
+			return err
+		}
+		byt, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		docs[p] = byt
+		return nil
+	})
+	return docs, err
+}
+
+// Warning records a JSON schema keyword which couldn't be imported into cue.
+// The generated cue is less strict than the schema, as the keyword's
+// constraint is missing.
+type Warning struct {
+	// Document is the URI of the document containing the keyword.
+	Document string
+	// Path is the JSON pointer to the keyword within the document, eg.
+	// "/properties/data/if".
+	Path string
+	// Keyword is the unsupported keyword.
+	Keyword string
+	// Message describes why the keyword isn't supported.
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s#%s: %s", w.Document, w.Path, w.Message)
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// Formats imports well-known "format" keywords, eg. "email" or "uuid", as
+	// pattern constraints.  "format" is an annotation which validators needn't
+	// enforce, and the patterns may be stricter than the format, eg. "uri"
+	// patterns only accept http(s) URIs.  By default, formatted values are
+	// imported as strings and each format is reported as a warning.
+	Formats bool
+}
+
+// Imported is cue imported from one or more JSON schema documents.
+type Imported struct {
+	// Cue is the cue type for the root schema.  Definitions within "$defs"
+	// or "definitions" become named cue definitions, eg. "#Address".
+	Cue string
+	// Warnings lists each keyword which couldn't be imported.
+	Warnings []Warning
+}
+
+// Import imports the JSON schema document with the given URI as a cue type.
+// Drafts 4 through 2020-12 are supported.
+//
+// References to other documents are loaded using the resolver and included as
+// named definitions, so that the generated cue is standalone.  Each document
+// is named after its path, eg. references to "customer.json" create a
+// "#customer" definition.
+//
+// Keywords which have no equivalent cue constraint are reported as warnings.
+func Import(uri string, r Resolver, o ImportOptions) (Imported, error) {
+	schema, warnings, err := bundle(uri, r, o)
+	if err != nil {
+		return Imported{}, err
+	}
+
+	f, err := extract(schema)
+	if err != nil {
+		return Imported{}, err
+	}
+
+	// Ensure that the generated cue is valid before formatting the file
+	// as-is, which retains references to each definition.
+	if _, err := (&cue.Runtime{}).CompileFile(f); err != nil {
+		return Imported{}, err
+	}
+	str, err := formatNode(f, format.Simplify())
+	if err != nil {
+		return Imported{}, err
+	}
+	return Imported{Cue: str, Warnings: warnings}, nil
+}
+
+// bundle bundles the JSON schema document with the given URI and every
+// document it references into a single, standalone document.
+func bundle(uri string, r Resolver, o ImportOptions) ([]byte, []Warning, error) {
+	i := &importer{
+		resolver: r,
+		o:        o,
+		names:    map[string]string{},
+		included: map[*orderedjson.Object]bool{},
+	}
+
+	root, err := i.load(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i.root = root
+	i.defsKey = "$defs"
+	i.defs, _ = root.Values["$defs"].(*orderedjson.Object)
+	if _, ok := root.Values["definitions"].(*orderedjson.Object); ok && i.defs == nil {
+		i.defsKey = "definitions"
+		i.defs = root.Values["definitions"].(*orderedjson.Object)
+	}
+
+	base := documentBase(uri, root)
+	i.names[base] = ""
+	if err := i.walk(root, base, base, ""); err != nil {
+		return nil, nil, err
+	}
+
+	byt, err := json.Marshal(root)
+	return byt, i.warnings, err
+}
+
+// extract converts a single, standalone JSON schema document to cue.
+func extract(schema []byte) (*ast.File, error) {
+	// Decode the schema into a cue.Instance.
+	r := &cue.Runtime{}
+	in, err := cuejson.Decode(r, "", schema)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := jsonschema.Extract(in, &jsonschema.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, fmt.Errorf("no definition generated from json schema")
+	}
+	if err := astutil.Sanitize(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// importer bundles JSON schema documents into a single document, rewriting
+// keywords which cue doesn't support where possible.
+type importer struct {
+	resolver Resolver
+	o        ImportOptions
+	warnings []Warning
+
+	// root is the root document, and defs is the root document's
+	// definitions, in which referenced documents are included.
+	root *orderedjson.Object
+	defs *orderedjson.Object
+	// defsKey is the keyword of the root document's definitions, ie.
+	// "$defs" or "definitions" for drafts prior to 2019-09.
+	defsKey string
+
+	// names stores the definition name for each document by URI.  The root
+	// document has an empty name.
+	names map[string]string
+	// included stores each document included within the root document's
+	// definitions, which are walked when included.
+	included map[*orderedjson.Object]bool
+}
+
+func (i *importer) load(uri string) (*orderedjson.Object, error) {
+	if i.resolver == nil {
+		return nil, fmt.Errorf("unable to resolve %s: no resolver", uri)
+	}
+	byt, err := i.resolver.Resolve(uri)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s: %w", uri, err)
+	}
+	doc, err := parseDocument(byt)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", uri, err)
+	}
+	return doc, nil
+}
+
+// include includes the document with the given URI as a definition within the
+// root document, returning the definition's name.
+func (i *importer) include(uri string) (string, error) {
+	if name, ok := i.names[uri]; ok {
+		return name, nil
+	}
+
+	doc, err := i.load(uri)
+	if err != nil {
+		return "", err
+	}
+
+	name := i.name(uri)
+	base := documentBase(uri, doc)
+	i.names[uri] = name
+	i.names[base] = name
+
+	if i.defs == nil {
+		i.defs = orderedjson.NewObject()
+		i.root.Set(i.defsKey, i.defs)
+	}
+	i.defs.Set(name, doc)
+	i.included[doc] = true
+
+	// The document's ID must be removed, as cue resolves references within
+	// the document relative to its ID.  Every reference is rewritten to be
+	// relative to the root document.
+	doc.Delete("$id")
+	doc.Delete("$schema")
+	return name, i.walk(doc, uri, base, "/"+i.defsKey+"/"+escape(name))
+}
+
+// name returns a unique definition name for the given document URI.
+func (i *importer) name(uri string) string {
+	u, _ := url.Parse(uri)
+	base := path.Base(u.Path)
+	base = strings.TrimSuffix(base, path.Ext(base))
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, base)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "schema" + name
+	}
+
+	unique := name
+	for n := 2; i.defs != nil; n++ {
+		if _, ok := i.defs.Get(unique); !ok {
+			break
+		}
+		unique = name + strconv.Itoa(n)
+	}
+	return unique
+}
+
+// walk walks a schema, rewriting references and unsupported keywords.  doc is
+// the URI of the document containing the schema, base is the URI which
+// references are resolved against, and prefix is the JSON pointer of the
+// document within the root document.
+func (i *importer) walk(schema *orderedjson.Object, doc, base, prefix string) error {
+	return i.walkSchema(schema, doc, base, prefix, "")
+}
+
+func (i *importer) walkSchema(v interface{}, doc, base, prefix, ptr string) error {
+	schema, ok := v.(*orderedjson.Object)
+	if !ok {
+		// Boolean schemas have no keywords.
+		return nil
+	}
+
+	if id, ok := schema.Values["$id"].(string); ok && ptr != "" {
+		base = resolve(base, id)
+	}
+
+	i.rewrite(schema, doc, ptr)
+
+	// Copy the keys, as rewriting references may add definitions to the
+	// root document while walking its keys.
+	keys := append([]string{}, schema.Keys...)
+	for _, key := range keys {
+		val := schema.Values[key]
+		at := ptr + "/" + escape(key)
+
+		switch key {
+		case "$ref":
+			ref, ok := val.(string)
+			if !ok {
+				continue
+			}
+			rewritten, err := i.ref(ref, base, prefix)
+			if err != nil {
+				return fmt.Errorf("%s#%s: %w", doc, at, err)
+			}
+			schema.Values[key] = rewritten
+
+		case "items", "additionalItems", "additionalProperties", "contains",
+			"propertyNames", "not", "if", "then", "else",
+			"unevaluatedProperties", "unevaluatedItems":
+			if list, ok := val.([]interface{}); ok {
+				if err := i.walkList(list, doc, base, prefix, at); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := i.walkSchema(val, doc, base, prefix, at); err != nil {
+				return err
+			}
+
+		case "allOf", "anyOf", "oneOf":
+			list, _ := val.([]interface{})
+			if err := i.walkList(list, doc, base, prefix, at); err != nil {
+				return err
+			}
+
+		case "properties", "patternProperties", "$defs", "definitions",
+			"dependentSchemas", "dependencies":
+			obj, ok := val.(*orderedjson.Object)
+			if !ok {
+				continue
+			}
+			for _, k := range append([]string{}, obj.Keys...) {
+				if doc, ok := obj.Values[k].(*orderedjson.Object); ok && i.included[doc] {
+					continue
+				}
+				if err := i.walkSchema(obj.Values[k], doc, base, prefix, at+"/"+escape(k)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (i *importer) walkList(list []interface{}, doc, base, prefix, ptr string) error {
+	for n, item := range list {
+		if err := i.walkSchema(item, doc, base, prefix, ptr+"/"+strconv.Itoa(n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ref rewrites a reference so that it's relative to the root document,
+// including any referenced documents as definitions.
+func (i *importer) ref(ref, base, prefix string) (string, error) {
+	resolved := resolve(base, ref)
+	uri, fragment := splitFragment(resolved)
+
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		// Anchors are left as-is, and cue reports them as unsupported.
+		return ref, nil
+	}
+
+	if uri == base || uri == "" {
+		return "#" + prefix + fragment, nil
+	}
+
+	name, ok := i.names[uri]
+	if !ok {
+		var err error
+		if name, err = i.include(uri); err != nil {
+			return "", err
+		}
+	}
+	if name == "" {
+		// This references the root document.
+		return "#" + fragment, nil
+	}
+	return "#/" + i.defsKey + "/" + escape(name) + fragment, nil
+}
+
+// rewrite rewrites keywords introduced after draft 7, which cue doesn't
+// support, to equivalent draft 7 keywords.  Keywords which can't be rewritten
+// are recorded as warnings.
+func (i *importer) rewrite(schema *orderedjson.Object, doc, ptr string) {
+	_, composed := schema.Values["allOf"]
+	for _, k := range []string{"anyOf", "oneOf", "$ref"} {
+		if _, ok := schema.Values[k]; ok {
+			composed = true
+		}
+	}
+
+	for _, key := range append([]string{}, schema.Keys...) {
+		val := schema.Values[key]
+
+		switch {
+		case key == "prefixItems":
+			i.prefixItems(schema)
+			continue
+		case key == "items" && isList(schema.Values["prefixItems"]):
+			// This is handled with prefixItems.
+			continue
+		case key == "unevaluatedProperties" && !composed && !schema.Has("additionalProperties"):
+			// Without composition, every property is evaluated by
+			// "properties" or "patternProperties", so this is equivalent
+			// to additionalProperties.
+			schema.Rename(key, "additionalProperties")
+			continue
+		case key == "format" && i.o.Formats:
+			f, _ := val.(string)
+			if pattern := formats.Pattern(formats.Format(f)); pattern != "" {
+				if !schema.Has("pattern") {
+					schema.Set("pattern", pattern)
+				}
+				continue
+			}
+		case supported[key] || ignored[key] || strings.HasPrefix(key, "x-"):
+			continue
+		}
+
+		i.warnings = append(i.warnings, Warning{
+			Document: doc,
+			Path:     ptr + "/" + escape(key),
+			Keyword:  key,
+			Message:  unsupportedMessage(key, val),
+		})
+	}
+}
+
+// prefixItems rewrites a draft 2020-12 tuple to the equivalent draft 7 tuple,
+// in which "items" is an array and "additionalItems" constrains the remaining
+// items.
+func (i *importer) prefixItems(schema *orderedjson.Object) {
+	additional, hasItems := schema.Values["items"]
+	schema.Delete("items")
+	schema.Rename("prefixItems", "items")
+
+	switch {
+	case !hasItems:
+		// Further items are allowed without constraint.
+		schema.Set("additionalItems", orderedjson.NewObject())
+	case additional == false:
+		// The tuple is closed, which cue's lists are by default.
+	default:
+		schema.Set("additionalItems", additional)
+	}
+}
+
+func unsupportedMessage(key string, val interface{}) string {
+	switch key {
+	case "format":
+		if f, _ := val.(string); formats.Pattern(formats.Format(f)) != "" {
+			return fmt.Sprintf("format %v is not enforced", val)
+		}
+		return fmt.Sprintf("unsupported format %v", val)
+	case "unevaluatedProperties":
+		return "unevaluatedProperties is only supported without allOf, anyOf, oneOf or $ref"
+	}
+	return fmt.Sprintf("unsupported keyword %q", key)
+}
+
+// supported lists the keywords which cue imports.
+var supported = map[string]bool{
+	"$schema": true, "$id": true, "$ref": true, "$defs": true, "definitions": true,
+	"$comment": true, "type": true, "enum": true, "const": true, "nullable": true,
+	"default": true, "deprecated": true, "examples": true, "description": true,
+	"title": true, "allOf": true, "anyOf": true, "oneOf": true, "pattern": true,
+	"minLength": true, "maxLength": true, "contentMediaType": true,
+	"contentEncoding": true, "minimum": true, "exclusiveMinimum": true,
+	"maximum": true, "exclusiveMaximum": true, "multipleOf": true,
+	"properties": true, "required": true, "propertyNames": true,
+	"minProperties": true, "maxProperties": true, "dependencies": true,
+	"patternProperties": true, "additionalProperties": true, "items": true,
+	"additionalItems": true, "contains": true, "minItems": true,
+	"maxItems": true, "uniqueItems": true,
+}
+
+// ignored lists annotations which don't constrain values, so aren't
+// reported as warnings.
+var ignored = map[string]bool{
+	"readOnly": true, "writeOnly": true, "$vocabulary": true,
+}
+
+func isList(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
+
+// documentBase returns the URI which references within a document are
+// resolved against:  the document's "$id", or the URI it was loaded from.
+func documentBase(uri string, doc *orderedjson.Object) string {
+	if id, ok := doc.Values["$id"].(string); ok {
+		return trimFragment(resolve(uri, id))
+	}
+	return trimFragment(uri)
+}
+
+func resolve(base, ref string) string {
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	if b.IsAbs() || r.IsAbs() {
+		return b.ResolveReference(r).String()
+	}
+
+	// URL resolution makes every path absolute, so relative paths such as
+	// "schemas/event.json" are resolved relative to the base's directory,
+	// as with files.
+	resolved := *r
+	switch {
+	case r.Path == "":
+		resolved.Path = b.Path
+	case !path.IsAbs(r.Path):
+		resolved.Path = path.Join(path.Dir(b.Path), r.Path)
+	}
+	return resolved.String()
+}
+
+func splitFragment(uri string) (string, string) {
+	n := strings.Index(uri, "#")
+	if n < 0 {
+		return uri, ""
+	}
+	return uri[:n], uri[n+1:]
+}
+
+func trimFragment(uri string) string {
+	u, _ := splitFragment(uri)
+	return u
+}
+
+// escape escapes a JSON pointer token.
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,108 @@
+package jsonschema
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	docs, err := ReadDocuments(os.DirFS("./testdata/webhook"))
+	require.NoError(t, err)
+
+	imported, err := Import("event.json", docs, ImportOptions{})
+	require.NoError(t, err)
+
+	// Referenced documents become definitions, and 2020-12 keywords are
+	// rewritten to their draft 7 equivalents.  Formats are annotations, so
+	// aren't enforced by default.
+	expected, err := os.ReadFile("./testdata/webhook.cue")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), imported.Cue)
+
+	warnings := []string{}
+	for _, w := range imported.Warnings {
+		warnings = append(warnings, w.String())
+	}
+	require.Equal(t, []string{
+		"https://example.com/schemas/event.json#/properties/id/format: format uuid is not enforced",
+		"https://example.com/schemas/event.json#/properties/created/format: format date-time is not enforced",
+		"https://example.com/schemas/customer.json#/properties/email/format: format email is not enforced",
+		"https://example.com/schemas/customer.json#/properties/phone/format: unsupported format phone",
+		`https://example.com/schemas/event.json#/properties/discount/if: unsupported keyword "if"`,
+		`https://example.com/schemas/event.json#/properties/discount/then: unsupported keyword "then"`,
+		`https://example.com/schemas/event.json#/properties/discount/dependentRequired: unsupported keyword "dependentRequired"`,
+	}, warnings)
+
+	// Documents can be resolved by their $id.
+	byID, err := Import("https://example.com/schemas/event.json", docs, ImportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, imported, byID)
+
+	// Well-known formats are imported as patterns when enabled.
+	imported, err = Import("event.json", docs, ImportOptions{Formats: true})
+	require.NoError(t, err)
+	expected, err = os.ReadFile("./testdata/webhook.formats.cue")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), imported.Cue)
+	require.Len(t, imported.Warnings, 4)
+
+	// The imported cue validates events.
+	r := &cue.Runtime{}
+	inst, err := r.Compile("webhook.cue", imported.Cue)
+	require.NoError(t, err)
+
+	valid := `{
+		"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		"type": "customer.created",
+		"data": {"email": "jane@example.com", "address": {"line1": "1 Main St", "country": "US"}},
+		"coordinates": [51.5, -0.12],
+		"tags": ["vip", 1, 2]
+	}`
+	invalid := map[string]string{
+		"format": `{"id": "1", "type": "customer.created", "data": {"email": "jane@example.com"}}`,
+		"enum":   `{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "type": "customer.deleted", "data": {"email": "jane@example.com"}}`,
+		"ref":    `{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "type": "customer.created", "data": {"address": {}}}`,
+		"tuple":  `{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "type": "customer.created", "data": {"email": "jane@example.com"}, "coordinates": [1, 2, 3]}`,
+		"length": `{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "type": "customer.created", "data": {"email": "jane@example.com", "address": {"line1": "a", "country": "USA"}}}`,
+	}
+
+	event, err := r.Compile("valid.json", valid)
+	require.NoError(t, err)
+	require.NoError(t, inst.Value().Unify(event.Value()).Validate(cue.Concrete(true)))
+
+	for name, data := range invalid {
+		event, err := r.Compile(name+".json", data)
+		require.NoError(t, err)
+		require.Error(t, inst.Value().Unify(event.Value()).Validate(cue.Concrete(true)), name)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	_, err := Import("event.json", Documents{
+		"event.json": []byte(`{"properties": {"customer": {"$ref": "customer.json"}}}`),
+	}, ImportOptions{})
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "unable to resolve customer.json"), err.Error())
+
+	_, err = Import("event.json", Documents{"event.json": []byte(`[]`)}, ImportOptions{})
+	require.Error(t, err)
+
+	_, err = UnmarshalString(`{"properties": {"customer": {"$ref": "customer.json"}}}`)
+	require.Error(t, err)
+}
+
+func TestUnmarshalStringWarnings(t *testing.T) {
+	str, warnings, err := UnmarshalStringWarnings(`{
+		"type": "object",
+		"properties": {"website": {"type": "string", "format": "uri"}}
+	}`)
+	require.NoError(t, err)
+	require.Contains(t, str, "website?: string")
+	require.Len(t, warnings, 1)
+	require.Equal(t, "/properties/website/format", warnings[0].Path)
+	require.Equal(t, "format uri is not enforced", warnings[0].Message)
+}
@@ -3,16 +3,19 @@ package jsonschema
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/ast"
-	"cuelang.org/go/cue/ast/astutil"
 	"cuelang.org/go/cue/format"
-	cuejson "cuelang.org/go/encoding/json"
-	"cuelang.org/go/encoding/jsonschema"
 	"cuelang.org/go/encoding/openapi"
 )
 
+const (
+	componentsPrefix = "#/components/schemas/"
+	defsPrefix       = "#/$defs/"
+)
+
 var (
 	c = &openapi.Config{
 		PkgName: "",
@@ -21,23 +24,27 @@ var (
 )
 
 // UnmarshalString returns a cue type for an event from a JSON schema definition.
+// The schema must be standalone;  use Import to import schemas which reference
+// other documents.
+//
+// Keywords which couldn't be imported are ignored;  use UnmarshalStringWarnings
+// to list them.
 func UnmarshalString(schema string) (string, error) {
-	// Decode the schema into a cue.Instance.
-	r := &cue.Runtime{}
-	in, err := cuejson.Decode(r, "", []byte(schema))
+	str, _, err := UnmarshalStringWarnings(schema)
+	return str, err
+}
+
+// UnmarshalStringWarnings is like UnmarshalString, but also returns a warning
+// for each keyword which couldn't be imported.
+func UnmarshalStringWarnings(schema string) (string, []Warning, error) {
+	bundled, warnings, err := bundle("", Documents{"": []byte(schema)}, ImportOptions{})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	expr, err := jsonschema.Extract(in, &jsonschema.Config{})
+	f, err := extract(bundled)
 	if err != nil {
-		return "", err
-	}
-	if expr == nil {
-		return "", fmt.Errorf("no definition generated from json schema")
-	}
-	if err := astutil.Sanitize(expr); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	// By default, this returns event data as top-level values, ie. not wrapped
@@ -48,12 +55,14 @@ func UnmarshalString(schema string) (string, error) {
 	// {
 	//    name: "..."
 	// }
-	instance, err := r.CompileFile(expr)
+	r := &cue.Runtime{}
+	instance, err := r.CompileFile(f)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return formatValue(instance.Value())
+	str, err := formatValue(instance.Value())
+	return str, warnings, err
 }
 
 // MarshalString generates OpenAPI schemas given cue configuration.  Schemas are
@@ -105,6 +114,63 @@ func MarshalCueValue(v cue.Value) (map[string]interface{}, error) {
 	return schemas.Find("event"), nil
 }
 
+// MarshalDefinitions generates an OpenAPI schema for the given top-level
+// identifier within the cue configuration.  Every other schema generated is
+// included within the schema's "$defs", and references to those schemas are
+// rewritten to point to "$defs", so that the schema is standalone:
+//
+//	#event: {
+//		sender: #User
+//	}
+//	#User: {
+//		login: string
+//	}
+func MarshalDefinitions(cuestr string, identifier string) (map[string]interface{}, error) {
+	schemas, err := MarshalString(cuestr)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := schemas.Find(identifier)
+	if schema == nil {
+		return nil, fmt.Errorf("no schema generated for %s", identifier)
+	}
+
+	defs := map[string]interface{}{}
+	for name, s := range schemas.All {
+		if name == identifier {
+			continue
+		}
+		defs[name] = rewriteRefs(s)
+	}
+
+	schema = rewriteRefs(schema).(map[string]interface{})
+	if len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+	return schema, nil
+}
+
+// rewriteRefs rewrites each OpenAPI component reference within the schema to
+// a reference within "$defs".
+func rewriteRefs(schema interface{}) interface{} {
+	switch t := schema.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			if ref, ok := v.(string); ok && k == "$ref" {
+				t[k] = strings.Replace(ref, componentsPrefix, defsPrefix, 1)
+				continue
+			}
+			t[k] = rewriteRefs(v)
+		}
+	case []interface{}:
+		for n, v := range t {
+			t[n] = rewriteRefs(v)
+		}
+	}
+	return schema
+}
+
 // Schemas stores all schemas generated for a cue file.
 type Schemas struct {
 	// All stores all generated schemas, in a map.
@@ -141,10 +207,10 @@ func formatValue(input cue.Value, opts ...cue.Option) (string, error) {
 }
 
 func formatNode(input ast.Node, opts ...format.Option) (string, error) {
-	out, err := format.Node(
-		input,
+	opts = append([]format.Option{
 		format.TabIndent(false),
 		format.UseSpaces(2),
-	)
+	}, opts...)
+	out, err := format.Node(input, opts...)
 	return string(out), err
 }
@@ -120,3 +120,49 @@ func TestUnmarshalString(t *testing.T) {
 	require.Equal(t, expected, actual, "Received:\n%s\n", actual)
 
 }
+
+func TestMarshalDefinitions(t *testing.T) {
+	input := `#event: {
+  sender: #User
+}
+
+#User: {
+  login: string
+  org?:  #Org
+}
+
+#Org: {
+  name: string
+}`
+
+	actual, err := MarshalDefinitions(input, "event")
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"sender"},
+		"properties": map[string]interface{}{
+			"sender": map[string]interface{}{
+				"$ref": "#/$defs/User",
+			},
+		},
+		"$defs": map[string]interface{}{
+			"User": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"login"},
+				"properties": map[string]interface{}{
+					"login": map[string]interface{}{"type": "string"},
+					"org":   map[string]interface{}{"$ref": "#/$defs/Org"},
+				},
+			},
+			"Org": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"name"},
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+	require.Equal(t, expected, actual)
+}
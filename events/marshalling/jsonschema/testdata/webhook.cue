@@ -0,0 +1,29 @@
+import "strings"
+
+@jsonschema(schema="https://json-schema.org/draft/2020-12/schema")
+@jsonschema(id="https://example.com/schemas/event.json")
+id:        string
+created?:  string
+type:      #type
+data:      #customer
+previous?: #customer.#address
+coordinates?: [number, number]
+tags?: [string, ...int]
+metadata?: source?: string
+discount?: _
+
+#type: "customer.created" | "customer.updated"
+
+#customer: {
+  email:    string
+  phone?:   string
+  address?: #customer.#address
+
+  #address: {
+    line1:    string
+    country?: strings.MinRunes(2) & strings.MaxRunes(2)
+    ...
+  }
+  ...
+}
+...
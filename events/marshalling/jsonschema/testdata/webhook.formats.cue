@@ -0,0 +1,29 @@
+import "strings"
+
+@jsonschema(schema="https://json-schema.org/draft/2020-12/schema")
+@jsonschema(id="https://example.com/schemas/event.json")
+id:        =~"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"
+created?:  =~"^\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}(\\.\\d+)?(Z|[+-]\\d{2}:\\d{2})$"
+type:      #type
+data:      #customer
+previous?: #customer.#address
+coordinates?: [number, number]
+tags?: [string, ...int]
+metadata?: source?: string
+discount?: _
+
+#type: "customer.created" | "customer.updated"
+
+#customer: {
+  email:    =~"^[^@\\s]+@[^@\\s]+\\.[^@\\s]+$"
+  phone?:   string
+  address?: #customer.#address
+
+  #address: {
+    line1:    string
+    country?: strings.MinRunes(2) & strings.MaxRunes(2)
+    ...
+  }
+  ...
+}
+...
@@ -3,11 +3,17 @@ package marshalling
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/ast"
 	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/token"
+
+	// Register cue's builtin packages, such as "strings", so that schemas
+	// using builtin validators compile.
+	_ "cuelang.org/go/pkg"
 )
 
 type Generator interface {
@@ -80,6 +86,16 @@ func Parse(ctx context.Context, v cue.Value) ([]ParsedAST, error) {
 }
 
 func parseAST(ctx context.Context, label string, v cue.Value) (ParsedAST, error) {
+	// References to other top-level definitions, eg. `sender: #User`, are
+	// kept as idents so that generators can refer to the named type instead
+	// of inlining the definition.
+	if name := definitionReference(v); name != "" && name != label {
+		return &ParsedIdent{
+			name:  label,
+			Ident: ast.NewIdent(name),
+		}, nil
+	}
+
 	// We have the cue's value, although this may represent many things.
 	// Notably, v.IncompleteKind() returns cue.StringKind even if this field
 	// represents a static string, a string type, or an enum of strings.
@@ -114,6 +130,15 @@ func parseAST(ctx context.Context, label string, v cue.Value) (ParsedAST, error)
 func parseCueSyntax(ctx context.Context, label string, v cue.Value, syn ast.Node) (ParsedAST, error) {
 	switch ident := syn.(type) {
 	case *ast.UnaryExpr:
+		if ident.Op != token.MUL {
+			// This is a bound whose type was simplified away, eg.
+			// `number & < 0` is represented as `< 0`.
+			value, err := astToValue(ident)
+			if err != nil {
+				return nil, fmt.Errorf("error converting syntax bound to value: %w", err)
+			}
+			return parseConstraintedIdent(ctx, label, value)
+		}
 		// This is a default value.
 		return nil, nil
 	case *ast.BinaryExpr:
@@ -208,10 +233,28 @@ func parseCueSyntax(ctx context.Context, label string, v cue.Value, syn ast.Node
 		}
 		return parseScalar(ctx, label, value)
 	case *ast.Ident:
-		return &ParsedIdent{
+		parsed := &ParsedIdent{
 			name:  label,
 			Ident: ident,
-		}, nil
+		}
+		if bits, ok := intWidths[ident.Name]; ok {
+			parsed.Constraints = []Constraint{{Kind: ConstraintWidth, Value: bits}}
+		}
+		return parsed, nil
+	case *ast.CallExpr:
+		// This is a builtin validator without a type, eg.
+		// `strings.MinRunes(3)`.
+		return parseConstraintedIdent(ctx, label, v)
+	case *ast.File:
+		// Values which reference builtin packages, such as
+		// `string & strings.MinRunes(3)`, are returned as a file containing
+		// the package imports.  Parse the embedded expression.
+		for _, decl := range ident.Decls {
+			if embed, ok := decl.(*ast.EmbedDecl); ok {
+				return parseCueSyntax(ctx, label, v, embed.Expr)
+			}
+		}
+		return nil, fmt.Errorf("unhandled cue file: %v", v)
 	case *ast.StructLit:
 		// Convert this to a value then iterate through the cue.StructKind value.
 		value, err := astToValue(ident)
@@ -224,6 +267,18 @@ func parseCueSyntax(ctx context.Context, label string, v cue.Value, syn ast.Node
 	}
 }
 
+// definitionReference returns the name of the top-level definition that the
+// value refers to, or an empty string if the value isn't a single reference to
+// a top-level definition.
+func definitionReference(v cue.Value) string {
+	_, path := v.ReferencePath()
+	sels := path.Selectors()
+	if len(sels) != 1 || !sels[0].IsDefinition() {
+		return ""
+	}
+	return sels[0].String()
+}
+
 // parseStruct returns a ParsedStruct with all of the cue fields parsed as
 // members.
 func parseStruct(ctx context.Context, v cue.Value) (*ParsedStruct, error) {
@@ -311,7 +366,24 @@ func parseArray(ctx context.Context, label string, v cue.Value) (*ParsedArray, e
 
 	elts := listLit.Elts
 	if ellipsis, ok := listLit.Elts[0].(*ast.Ellipsis); ok {
-		// TODO: ???
+		if ellipsis.Type == nil {
+			// An open list with no type, eg. `[...]`, can contain
+			// any value.
+			parsed.Members = append(parsed.Members, &ParsedIdent{
+				Ident: ast.NewIdent("_"),
+			})
+			return parsed, nil
+		}
+		// Parse the element's value directly, as the element type may
+		// have constraints which can only be walked via its value.
+		if elem := v.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() {
+			p, err := parseAST(ctx, "", elem)
+			if err != nil {
+				return parsed, err
+			}
+			parsed.Members = append(parsed.Members, p)
+			return parsed, nil
+		}
 		elts = []ast.Expr{ellipsis.Type}
 	}
 
@@ -346,6 +418,14 @@ func parseEnum(ctx context.Context, label string, v cue.Value) (*ParsedEnum, err
 		enum.Members = append(enum.Members, ast)
 	}
 
+	if def, ok := v.Default(); ok && def.IsConcrete() {
+		parsed, err := parseAST(ctx, "", def)
+		if err != nil {
+			return enum, fmt.Errorf("error generating ast for enum default: %w", err)
+		}
+		enum.Default = parsed
+	}
+
 	return enum, nil
 }
 
@@ -359,11 +439,181 @@ func parseScalar(ctx context.Context, label string, v cue.Value) (ParsedAST, err
 	return &ParsedScalar{name: label, Value: i}, nil
 }
 
+// parseConstraintedIdent parses a type combined with constraints, eg.
+// `uint & <= 10`, by walking the tree of AndOps within the value.  The first
+// operand which isn't a constraint is used as the ident's type.
 func parseConstraintedIdent(ctx context.Context, label string, v cue.Value) (ParsedAST, error) {
-	// TODO: Parse constraints and include within the ident.
-	_, vals := v.Expr()
-	// Hack: take the first value which is always the constrained ident.
-	return parseAST(ctx, label, vals[0])
+	var parsed ParsedAST
+	constraints := []Constraint{}
+
+	stack := []cue.Value{v}
+	for len(stack) > 0 {
+		item := stack[0]
+		stack = stack[1:]
+
+		// Sized integers such as uint8 are themselves a conjunction of int
+		// and bounds.  Keep the type's name instead of expanding it.
+		if ident, ok := item.Syntax(cue.All()).(*ast.Ident); ok && parsed == nil {
+			if bits, ok := intWidths[ident.Name]; ok {
+				parsed = &ParsedIdent{name: label, Ident: ident}
+				constraints = appendConstraint(constraints, Constraint{Kind: ConstraintWidth, Value: bits})
+				continue
+			}
+		}
+
+		op, vals := item.Expr()
+		switch op {
+		case cue.AndOp:
+			// Walk each operand in order, before the remaining items.
+			stack = append(vals, stack...)
+		case cue.GreaterThanOp, cue.GreaterThanEqualOp, cue.LessThanOp, cue.LessThanEqualOp:
+			value, err := decodeConstraint(vals[0])
+			if err != nil {
+				return nil, err
+			}
+			c := Constraint{
+				Kind:      ConstraintMin,
+				Value:     value,
+				Exclusive: op == cue.GreaterThanOp || op == cue.LessThanOp,
+			}
+			if op == cue.LessThanOp || op == cue.LessThanEqualOp {
+				c.Kind = ConstraintMax
+			}
+			constraints = appendConstraint(constraints, c)
+		case cue.RegexMatchOp, cue.NotRegexMatchOp, cue.NotEqualOp:
+			value, err := decodeConstraint(vals[0])
+			if err != nil {
+				return nil, err
+			}
+			kind := ConstraintNotEqual
+			switch op {
+			case cue.RegexMatchOp:
+				kind = ConstraintRegex
+			case cue.NotRegexMatchOp:
+				kind = ConstraintNotRegex
+			}
+			constraints = appendConstraint(constraints, Constraint{Kind: kind, Value: value})
+		case cue.CallOp:
+			c, ok, err := parseCallConstraint(vals)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				constraints = appendConstraint(constraints, c)
+			}
+		case cue.NoOp, cue.OrOp:
+			if parsed != nil {
+				continue
+			}
+			p, err := parseAST(ctx, label, item)
+			if err != nil {
+				return nil, err
+			}
+			parsed = p
+		}
+	}
+
+	if parsed == nil {
+		// There's no type within the expression, eg. `>= 1 & <= 10`.
+		// Use the kind of the value as the ident.
+		parsed = &ParsedIdent{
+			name:  label,
+			Ident: ast.NewIdent(kindIdent(v.IncompleteKind())),
+		}
+	}
+
+	if ident, ok := parsed.(*ParsedIdent); ok && len(constraints) > 0 {
+		ident.Constraints = constraints
+	}
+	return parsed, nil
+}
+
+// parseCallConstraint returns the constraint for a call to a builtin
+// validator, eg. `strings.MinRunes(3)`.  Unknown validators are ignored.
+func parseCallConstraint(vals []cue.Value) (Constraint, bool, error) {
+	if len(vals) != 2 {
+		return Constraint{}, false, nil
+	}
+
+	// The function is a selector of the package and function name.
+	op, fn := vals[0].Expr()
+	if op != cue.SelectorOp || len(fn) != 2 {
+		return Constraint{}, false, nil
+	}
+	name, err := fn[1].String()
+	if err != nil {
+		return Constraint{}, false, nil
+	}
+
+	var kind ConstraintKind
+	switch name {
+	case "MinRunes":
+		kind = ConstraintMinLength
+	case "MaxRunes":
+		kind = ConstraintMaxLength
+	default:
+		return Constraint{}, false, nil
+	}
+
+	value, err := decodeConstraint(vals[1])
+	if err != nil {
+		return Constraint{}, false, err
+	}
+	return Constraint{Kind: kind, Value: value}, true, nil
+}
+
+// decodeConstraint decodes the operand of a constraint into a Go value.
+func decodeConstraint(v cue.Value) (interface{}, error) {
+	var value interface{}
+	if err := v.Decode(&value); err != nil {
+		return nil, fmt.Errorf("error decoding constraint: %w", err)
+	}
+	return value, nil
+}
+
+// appendConstraint adds the constraint to the list if it's not already present,
+// eg. as `uint & >= 0` specifies the same bound twice.
+func appendConstraint(list []Constraint, c Constraint) []Constraint {
+	for _, existing := range list {
+		if reflect.DeepEqual(existing, c) {
+			return list
+		}
+	}
+	return append(list, c)
+}
+
+// intWidths maps cue's sized integer types to their width in bits.
+var intWidths = map[string]int{
+	"int8":    8,
+	"int16":   16,
+	"int32":   32,
+	"int64":   64,
+	"int128":  128,
+	"uint8":   8,
+	"uint16":  16,
+	"uint32":  32,
+	"uint64":  64,
+	"uint128": 128,
+}
+
+// kindIdent returns the cue type name for the given kind.
+func kindIdent(k cue.Kind) string {
+	switch k {
+	case cue.NumberKind:
+		return "number"
+	case cue.IntKind:
+		return "int"
+	case cue.FloatKind:
+		return "float"
+	case cue.StringKind:
+		return "string"
+	case cue.BytesKind:
+		return "bytes"
+	case cue.BoolKind:
+		return "bool"
+	default:
+		return "_"
+	}
 }
 
 func Format(expr ...Expr) (string, error) {
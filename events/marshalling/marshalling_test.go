@@ -54,6 +54,10 @@ func TestParse(t *testing.T) {
 				&ParsedIdent{
 					name:  "#Def",
 					Ident: ast.NewIdent("int"),
+					Constraints: []Constraint{
+						{Kind: ConstraintMin, Value: 5},
+						{Kind: ConstraintMax, Value: 10},
+					},
 				},
 			},
 		},
@@ -64,12 +68,101 @@ func TestParse(t *testing.T) {
 				&ParsedIdent{
 					name:  "#Def",
 					Ident: ast.NewIdent("int"),
+					Constraints: []Constraint{
+						{Kind: ConstraintMin, Value: 5},
+						{Kind: ConstraintMax, Value: 10},
+					},
 					Default: &ParsedScalar{
 						Value: 8,
 					},
 				},
 			},
 		},
+		{
+			name:  "bounds without a type",
+			input: `#Def: > 1.5 & < 10`,
+			expected: []ParsedAST{
+				&ParsedIdent{
+					name:  "#Def",
+					Ident: ast.NewIdent("number"),
+					Constraints: []Constraint{
+						{Kind: ConstraintMin, Value: 1.5, Exclusive: true},
+						{Kind: ConstraintMax, Value: 10, Exclusive: true},
+					},
+				},
+			},
+		},
+		{
+			name:  "bound with simplified type",
+			input: `#Def: number & < 0`,
+			expected: []ParsedAST{
+				&ParsedIdent{
+					name:  "#Def",
+					Ident: ast.NewIdent("number"),
+					Constraints: []Constraint{
+						{Kind: ConstraintMax, Value: 0, Exclusive: true},
+					},
+				},
+			},
+		},
+		{
+			name:  "enum with default",
+			input: `#Def: "usd" | "eur" | *"usd"`,
+			expected: []ParsedAST{
+				&ParsedEnum{
+					name: "#Def",
+					Members: []ParsedAST{
+						&ParsedScalar{Value: "usd"},
+						&ParsedScalar{Value: "eur"},
+					},
+					Default: &ParsedScalar{Value: "usd"},
+				},
+			},
+		},
+		{
+			name:  "sized int with bounds",
+			input: `#Def: uint8 & > 5`,
+			expected: []ParsedAST{
+				&ParsedIdent{
+					name:  "#Def",
+					Ident: ast.NewIdent("uint8"),
+					Constraints: []Constraint{
+						{Kind: ConstraintWidth, Value: 8},
+						{Kind: ConstraintMin, Value: 5, Exclusive: true},
+					},
+				},
+			},
+		},
+		{
+			name: "string constraints",
+			input: `import "strings"
+			#Def: string & =~"^[a-z]+$" & !~"^admin" & strings.MinRunes(3) & strings.MaxRunes(10)`,
+			expected: []ParsedAST{
+				&ParsedIdent{
+					name:  "#Def",
+					Ident: ast.NewIdent("string"),
+					Constraints: []Constraint{
+						{Kind: ConstraintRegex, Value: "^[a-z]+$"},
+						{Kind: ConstraintNotRegex, Value: "^admin"},
+						{Kind: ConstraintMinLength, Value: 3},
+						{Kind: ConstraintMaxLength, Value: 10},
+					},
+				},
+			},
+		},
+		{
+			name:  "not equal",
+			input: `#Def: string & != "foo"`,
+			expected: []ParsedAST{
+				&ParsedIdent{
+					name:  "#Def",
+					Ident: ast.NewIdent("string"),
+					Constraints: []Constraint{
+						{Kind: ConstraintNotEqual, Value: "foo"},
+					},
+				},
+			},
+		},
 		// structs
 		{
 			name: "basic struct",
@@ -91,6 +184,9 @@ func TestParse(t *testing.T) {
 							ParsedAST: &ParsedIdent{
 								name:  "age",
 								Ident: ast.NewIdent("int"),
+								Constraints: []Constraint{
+									{Kind: ConstraintMin, Value: 0},
+								},
 								Default: &ParsedScalar{
 									Value: 21,
 								},
@@ -172,6 +268,71 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "definition references",
+			input: `#User: {
+				login: string
+			}
+			#Event: {
+				sender: #User
+				users: [...#User]
+				extended: {
+					#User
+					id: int
+				}
+			}`,
+			expected: []ParsedAST{
+				&ParsedStruct{
+					name: "#User",
+					Members: []*ParsedStructField{
+						{
+							ParsedAST: &ParsedIdent{
+								name:  "login",
+								Ident: ast.NewIdent("string"),
+							},
+						},
+					},
+				},
+				&ParsedStruct{
+					name: "#Event",
+					Members: []*ParsedStructField{
+						{
+							ParsedAST: &ParsedIdent{
+								name:  "sender",
+								Ident: ast.NewIdent("#User"),
+							},
+						},
+						{
+							ParsedAST: &ParsedArray{
+								name: "users",
+								Members: []ParsedAST{
+									&ParsedIdent{Ident: ast.NewIdent("#User")},
+								},
+							},
+						},
+						{
+							ParsedAST: &ParsedStruct{
+								name: "extended",
+								Members: []*ParsedStructField{
+									{
+										ParsedAST: &ParsedIdent{
+											name:  "id",
+											Ident: ast.NewIdent("int"),
+										},
+									},
+									{
+										ParsedAST: &ParsedIdent{
+											name:  "login",
+											Ident: ast.NewIdent("string"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		// arrays
 		{
 			name:  "basic array",
@@ -204,6 +365,20 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "open array",
+			input: `#Any: [...]`,
+			expected: []ParsedAST{
+				&ParsedArray{
+					name: "#Any",
+					Members: []ParsedAST{
+						&ParsedIdent{
+							Ident: ast.NewIdent("_"),
+						},
+					},
+				},
+			},
+		},
 		{
 			name:  "lit array",
 			input: `#Idents: ["person", "dog", "cat"]`,
@@ -238,3 +413,12 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendConstraint(t *testing.T) {
+	// Constraint values may be lists or structs, which aren't comparable.
+	list := []Constraint{{Kind: ConstraintMin, Value: []interface{}{"a"}}}
+	list = appendConstraint(list, Constraint{Kind: ConstraintMin, Value: []interface{}{"a"}})
+	require.Len(t, list, 1)
+	list = appendConstraint(list, Constraint{Kind: ConstraintMin, Value: map[string]interface{}{"a": 1}})
+	require.Len(t, list, 2)
+}
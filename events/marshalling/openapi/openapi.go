@@ -0,0 +1,305 @@
+// Package openapi generates OpenAPI 3.1 documents describing the webhooks
+// sent by each service, eg. for request validation or mock servers.  Services
+// send the event's data as the webhook's request body, rather than the Inngest
+// event which wraps the data, so request bodies are described by the schema
+// of each event's "data" field.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/inngest/event-schemas/events"
+	"github.com/inngest/event-schemas/events/marshalling/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Version = "3.1.0"
+
+	contentType = "application/json"
+
+	defsPrefix    = "#/$defs/"
+	schemasPrefix = "#/components/schemas/"
+
+	// generatedSummary summarizes examples generated from the schema.
+	generatedSummary = "Generated from the schema with fake data"
+)
+
+// invalidKey matches characters which aren't valid within component keys.
+var invalidKey = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Options configures the generated documents.
+type Options struct {
+	// APIVersion is the version of the API.  This defaults to "1.0.0".
+	APIVersion string
+}
+
+// Document is an OpenAPI 3.1 document.
+type Document struct {
+	OpenAPI    string                 `json:"openapi" yaml:"openapi"`
+	Info       Info                   `json:"info" yaml:"info"`
+	Webhooks   map[string]interface{} `json:"webhooks" yaml:"webhooks"`
+	Components Components             `json:"components" yaml:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Components stores the schema of each webhook's request body, along with
+// every definition the schemas reference.
+type Components struct {
+	Schemas map[string]interface{} `json:"schemas" yaml:"schemas"`
+}
+
+// JSON returns the document as indented JSON.
+func (d Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the document as YAML.
+func (d Document) YAML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateServices generates a document for each service, keyed by service.
+// Events without a service are skipped.
+func GenerateServices(evts []events.Event, o Options) (map[string]Document, error) {
+	byService := map[string][]events.Event{}
+	for _, evt := range evts {
+		if evt.Service != "" {
+			byService[evt.Service] = append(byService[evt.Service], evt)
+		}
+	}
+
+	docs := map[string]Document{}
+	for service, evts := range byService {
+		doc, err := Generate(service, evts, o)
+		if err != nil {
+			return nil, err
+		}
+		docs[service] = doc
+	}
+	return docs, nil
+}
+
+// Generate generates a document describing each event sent by a service as a
+// webhook.  Each webhook receives a POST request with the event's data as the
+// JSON request body, documented with the event's description and the data of
+// the event's examples.
+//
+// Request body schemas are draft 2020-12 schemas, generated by the jsonschema
+// package.  Definitions which schemas reference, eg. "#GitHubUser", are shared
+// between webhooks within the document's components.
+func Generate(service string, evts []events.Event, o Options) (Document, error) {
+	if o.APIVersion == "" {
+		o.APIVersion = "1.0.0"
+	}
+
+	doc := Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       fmt.Sprintf("%s webhooks", service),
+			Version:     o.APIVersion,
+			Description: fmt.Sprintf("Webhooks sent by %s.", service),
+		},
+		Webhooks: map[string]interface{}{},
+		Components: Components{
+			Schemas: map[string]interface{}{},
+		},
+	}
+
+	for _, evt := range evts {
+		key := Key(evt)
+		if _, ok := doc.Webhooks[key]; ok {
+			return Document{}, fmt.Errorf("duplicate webhook for %s", key)
+		}
+
+		schema, err := addSchemas(doc.Components.Schemas, key, evt)
+		if err != nil {
+			return Document{}, err
+		}
+		doc.Webhooks[key] = map[string]interface{}{
+			"post": operation(key, evt, schema),
+		}
+	}
+	return doc, nil
+}
+
+// Key returns the key of the event's webhook and request body schema, eg.
+// "stripe.charge.failed", or "acme.signup.v2" for a versioned event.
+func Key(evt events.Event) string {
+	key := strings.ReplaceAll(evt.Name, "/", ".")
+	if evt.Version != "" {
+		key += ".v" + evt.Version
+	}
+	return invalidKey.ReplaceAllString(key, "_")
+}
+
+func operation(key string, evt events.Event, schema interface{}) map[string]interface{} {
+	media := map[string]interface{}{"schema": schema}
+	if len(evt.Examples) > 0 {
+		examples := map[string]interface{}{}
+		for n, example := range evt.Examples {
+			data, ok := example["data"]
+			if !ok {
+				continue
+			}
+			item := map[string]interface{}{"value": data}
+			if evt.ExamplesGenerated {
+				// Mark examples generated from the schema, so that they
+				// aren't mistaken for captured events.
+				item["summary"] = generatedSummary
+				item["x-generated"] = true
+			}
+			examples[fmt.Sprintf("example%d", n+1)] = item
+		}
+		if len(examples) > 0 {
+			media["examples"] = examples
+		}
+	}
+
+	op := map[string]interface{}{
+		"operationId": key,
+		"requestBody": map[string]interface{}{
+			"required": true,
+			"content":  map[string]interface{}{contentType: media},
+		},
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "The event was received.",
+			},
+		},
+	}
+	if evt.Description != "" {
+		op["summary"] = evt.Description
+		op["description"] = evt.Description
+	}
+	return op
+}
+
+// addSchemas adds the schema of the event's data and the definitions it
+// references to the given components, returning a reference to the data's
+// schema.  Definitions are
+// shared between events, unless two events define different schemas with the
+// same name;  conflicting definitions are prefixed with the event's key.
+func addSchemas(components map[string]interface{}, key string, evt events.Event) (interface{}, error) {
+	if _, ok := components[key]; ok {
+		return nil, fmt.Errorf("duplicate schema for %s", key)
+	}
+
+	converted := jsonschema.Convert(evt.Schema, jsonschema.Options{Dialect: jsonschema.Dialect202012})
+	schema := map[string]interface{}{"type": "object"}
+	if props, ok := converted["properties"].(map[string]interface{}); ok {
+		if data, ok := props["data"].(map[string]interface{}); ok {
+			schema = data
+		}
+	}
+	all, _ := converted["$defs"].(map[string]interface{})
+	defs := map[string]interface{}{}
+	referenced(schema, all, defs)
+
+	// Sort definitions so that conflicts are named deterministically.
+	names := make([]string, 0, len(defs))
+	renamed := map[string]string{}
+	for name := range defs {
+		names = append(names, name)
+		renamed[name] = name
+	}
+	sort.Strings(names)
+
+	// Existing components have their references rewritten, so compare them
+	// to rewritten definitions.  Renaming a definition changes definitions
+	// which reference it, so repeat until no more definitions conflict.
+	for conflicts := true; conflicts; {
+		conflicts = false
+		for _, name := range names {
+			existing, ok := components[name]
+			if !ok || renamed[name] != name {
+				continue
+			}
+			if !reflect.DeepEqual(existing, rewriteRefs(defs[name], renamed)) {
+				renamed[name] = key + "." + name
+				conflicts = true
+			}
+		}
+	}
+
+	for _, name := range names {
+		components[renamed[name]] = rewriteRefs(defs[name], renamed)
+	}
+	components[key] = rewriteRefs(schema, renamed)
+	return map[string]interface{}{"$ref": schemasPrefix + key}, nil
+}
+
+// referenced adds each definition within all which the schema references,
+// directly or via other definitions, to defs.
+func referenced(schema interface{}, all, defs map[string]interface{}) {
+	switch t := schema.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			ref, ok := v.(string)
+			if !ok || k != "$ref" || !strings.HasPrefix(ref, defsPrefix) {
+				referenced(v, all, defs)
+				continue
+			}
+			name := strings.TrimPrefix(ref, defsPrefix)
+			if def, ok := all[name]; ok {
+				if _, seen := defs[name]; !seen {
+					defs[name] = def
+					referenced(def, all, defs)
+				}
+			}
+		}
+	case []interface{}:
+		for _, v := range t {
+			referenced(v, all, defs)
+		}
+	}
+}
+
+// rewriteRefs returns a copy of the schema in which each "$defs" reference
+// references the renamed component schema.
+func rewriteRefs(schema interface{}, renamed map[string]string) interface{} {
+	switch t := schema.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			if ref, ok := v.(string); ok && k == "$ref" && strings.HasPrefix(ref, defsPrefix) {
+				name := strings.TrimPrefix(ref, defsPrefix)
+				if to, ok := renamed[name]; ok {
+					name = to
+				}
+				m[k] = schemasPrefix + name
+				continue
+			}
+			m[k] = rewriteRefs(v, renamed)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(t))
+		for n, v := range t {
+			list[n] = rewriteRefs(v, renamed)
+		}
+		return list
+	}
+	return schema
+}
@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/inngest/event-schemas/events"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func user(props ...string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for _, p := range props {
+		properties[p] = map[string]interface{}{"type": "string"}
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+// withUser returns an event schema whose data references the given User
+// definition.  The event's user references a Sender definition, which isn't
+// part of the data.
+func withUser(name string, def map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"name", "data"},
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string", "enum": []interface{}{name}},
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"user": map[string]interface{}{"$ref": "#/$defs/User"},
+				},
+			},
+			"user": map[string]interface{}{"$ref": "#/$defs/Sender"},
+		},
+		"$defs": map[string]interface{}{"User": def, "Sender": user("id")},
+	}
+}
+
+var testEvents = []events.Event{
+	{
+		Name:        "acme/user.created",
+		Service:     "acme",
+		Description: "A user signed up",
+		Schema:      withUser("acme/user.created", user("id")),
+		Examples:    []map[string]interface{}{{"name": "acme/user.created", "data": map[string]interface{}{"user": map[string]interface{}{"id": "1"}}}},
+	},
+	{
+		Name:    "acme/user.deleted",
+		Service: "acme",
+		Schema:  withUser("acme/user.deleted", user("id")),
+	},
+	{
+		Name:              "acme/user.created",
+		Service:           "acme",
+		Version:           "2",
+		Schema:            withUser("acme/user.created", user("id", "email")),
+		Examples:          []map[string]interface{}{{"name": "acme/user.created", "data": map[string]interface{}{}, "v": "2"}},
+		ExamplesGenerated: true,
+	},
+	{
+		Name:    "other/ping",
+		Service: "other",
+		Schema:  map[string]interface{}{"type": "object"},
+	},
+	{
+		Name:   "ping",
+		Schema: map[string]interface{}{"type": "object"},
+	},
+}
+
+func TestGenerateServices(t *testing.T) {
+	docs, err := GenerateServices(testEvents, Options{})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Len(t, docs["acme"].Webhooks, 3)
+	require.Len(t, docs["other"].Webhooks, 1)
+}
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generate("acme", testEvents[:3], Options{})
+	require.NoError(t, err)
+	require.Equal(t, Version, doc.OpenAPI)
+	require.Equal(t, "acme webhooks", doc.Info.Title)
+	require.Equal(t, "1.0.0", doc.Info.Version)
+
+	require.Equal(t, map[string]interface{}{
+		"post": map[string]interface{}{
+			"operationId": "acme.user.created",
+			"summary":     "A user signed up",
+			"description": "A user signed up",
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/acme.user.created"},
+						"examples": map[string]interface{}{
+							"example1": map[string]interface{}{
+								"value": map[string]interface{}{"user": map[string]interface{}{"id": "1"}},
+							},
+						},
+					},
+				},
+			},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{"description": "The event was received."},
+			},
+		},
+	}, doc.Webhooks["acme.user.created"])
+	require.Contains(t, doc.Webhooks, "acme.user.created.v2")
+
+	// Generated examples are marked as such.
+	body := doc.Webhooks["acme.user.created.v2"].(map[string]interface{})["post"].(map[string]interface{})["requestBody"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"example1": map[string]interface{}{
+			"value":       map[string]interface{}{},
+			"summary":     "Generated from the schema with fake data",
+			"x-generated": true,
+		},
+	}, body["content"].(map[string]interface{})["application/json"].(map[string]interface{})["examples"])
+
+	// Request body schemas are draft 2020-12 schemas of the event's data,
+	// along with the definitions the data references.
+	schemas := doc.Components.Schemas
+	created := schemas["acme.user.created"].(map[string]interface{})
+	require.NotContains(t, created, "$schema")
+	require.NotContains(t, created, "$defs")
+	props := created["properties"].(map[string]interface{})
+	require.NotContains(t, props, "name")
+	require.NotContains(t, schemas, "Sender")
+
+	// Identical definitions are shared, and conflicting definitions are
+	// prefixed with the event's key.
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/User"}, props["user"])
+	deleted := schemas["acme.user.deleted"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/User"}, deleted["properties"].(map[string]interface{})["user"])
+	v2 := schemas["acme.user.created.v2"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/acme.user.created.v2.User"}, v2["properties"].(map[string]interface{})["user"])
+	require.Equal(t, user("id"), schemas["User"])
+	require.Equal(t, user("id", "email"), schemas["acme.user.created.v2.User"])
+
+	// The event's schema is left untouched.
+	require.Equal(t, "#/$defs/Sender", testEvents[0].Schema["properties"].(map[string]interface{})["user"].(map[string]interface{})["$ref"])
+
+	// YAML and JSON encode the same document.
+	byt, err := doc.JSON()
+	require.NoError(t, err)
+	fromJSON := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(byt, &fromJSON))
+
+	yml, err := doc.YAML()
+	require.NoError(t, err)
+	fromYAML := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(yml, &fromYAML))
+	require.Equal(t, fromJSON, fromYAML)
+}
+
+func TestGenerateNestedDefinitions(t *testing.T) {
+	withAddress := func(name string, address map[string]interface{}) events.Event {
+		schema := withUser(name, map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"address": map[string]interface{}{"$ref": "#/$defs/Address"}},
+		})
+		schema["$defs"].(map[string]interface{})["Address"] = address
+		return events.Event{Name: name, Service: "acme", Schema: schema}
+	}
+
+	// Definitions which reference other definitions are shared when
+	// they're identical.
+	doc, err := Generate("acme", []events.Event{
+		withAddress("acme/user.created", user("city")),
+		withAddress("acme/user.updated", user("city")),
+	}, Options{})
+	require.NoError(t, err)
+	require.Len(t, doc.Components.Schemas, 4)
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/Address"},
+		doc.Components.Schemas["User"].(map[string]interface{})["properties"].(map[string]interface{})["address"])
+
+	// Definitions which reference a conflicting definition also conflict.
+	doc, err = Generate("acme", []events.Event{
+		withAddress("acme/user.created", user("city")),
+		withAddress("acme/user.updated", user("city", "country")),
+	}, Options{})
+	require.NoError(t, err)
+	require.Contains(t, doc.Components.Schemas, "acme.user.updated.Address")
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/acme.user.updated.Address"},
+		doc.Components.Schemas["acme.user.updated.User"].(map[string]interface{})["properties"].(map[string]interface{})["address"])
+}
+
+func TestGenerateDuplicate(t *testing.T) {
+	_, err := Generate("acme", []events.Event{testEvents[0], testEvents[0]}, Options{})
+	require.EqualError(t, err, "duplicate webhook for acme.user.created")
+}
+
+func TestGenerateEvents(t *testing.T) {
+	docs, err := GenerateServices(events.Events, Options{})
+	require.NoError(t, err)
+	require.Contains(t, docs, "github")
+	require.Contains(t, docs, "stripe")
+
+	github := docs["github"]
+	require.Contains(t, github.Components.Schemas, "GitHubUser")
+	for key := range github.Webhooks {
+		require.Contains(t, github.Components.Schemas, key)
+	}
+}
@@ -0,0 +1,208 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inngest/event-schemas/events/marshalling"
+)
+
+const (
+	indent = "    "
+)
+
+var (
+	// stdlibModules lists the standard library modules that generated code
+	// may import.
+	stdlibModules = map[string]bool{
+		"enum":   true,
+		"typing": true,
+	}
+)
+
+var (
+	_ marshalling.Expr = Imports{}
+	_ marshalling.Expr = Alias{}
+	_ marshalling.Expr = Class{}
+	_ marshalling.Expr = TypedDict{}
+	_ marshalling.Expr = Enum{}
+)
+
+// Lit represents literal text, such as newlines, comments, spaces, etc.
+type Lit struct {
+	Value string
+}
+
+func (l Lit) String() string { return l.Value }
+
+// Imports represents the import statements for a generated module, keyed
+// by the module name:
+//
+//	from typing import List, Literal
+//
+// Standard library imports are grouped before third party imports.
+type Imports map[string][]string
+
+func (i Imports) String() string {
+	stdlib, thirdParty := []string{}, []string{}
+	for m := range i {
+		if stdlibModules[m] {
+			stdlib = append(stdlib, m)
+			continue
+		}
+		thirdParty = append(thirdParty, m)
+	}
+
+	groups := []string{}
+	for _, modules := range [][]string{stdlib, thirdParty} {
+		if len(modules) == 0 {
+			continue
+		}
+		sort.Strings(modules)
+		lines := []string{}
+		for _, m := range modules {
+			names := append([]string{}, i[m]...)
+			sort.Strings(names)
+			lines = append(lines, fmt.Sprintf("from %s import %s", m, strings.Join(names, ", ")))
+		}
+		groups = append(groups, strings.Join(lines, "\n"))
+	}
+	return strings.Join(groups, "\n\n")
+}
+
+// Alias represents a type alias:
+//
+//	Status = Literal["open", "closed"]
+type Alias struct {
+	Name string
+	Type string
+}
+
+func (a Alias) String() string {
+	return fmt.Sprintf("%s = %s", a.Name, a.Type)
+}
+
+// Field is a single annotated attribute within a class.
+type Field struct {
+	// Name is the attribute's Python identifier.
+	Name string
+	// Key is the original key of the field.  This may differ from the name
+	// if the key is not a valid Python identifier.
+	Key string
+	// Type is the type annotation for the field.
+	Type string
+	// Default is the optional right hand side of the attribute, eg. "None".
+	Default string
+}
+
+func (f Field) String() string {
+	if f.Default == "" {
+		return fmt.Sprintf("%s: %s", f.Name, f.Type)
+	}
+	return fmt.Sprintf("%s: %s = %s", f.Name, f.Type, f.Default)
+}
+
+// Class represents a class definition with annotated fields, such as a
+// pydantic model:
+//
+//	class Event(BaseModel):
+//	    name: str
+type Class struct {
+	Name   string
+	Base   string
+	Fields []Field
+}
+
+func (c Class) String() string {
+	str := strings.Builder{}
+	_, _ = str.WriteString(fmt.Sprintf("class %s(%s):\n", c.Name, c.Base))
+	if len(c.Fields) == 0 {
+		_, _ = str.WriteString(indent + "pass")
+		return str.String()
+	}
+	for n, f := range c.Fields {
+		_, _ = str.WriteString(indent + f.String())
+		if n < len(c.Fields)-1 {
+			_, _ = str.WriteString("\n")
+		}
+	}
+	return str.String()
+}
+
+// TypedDict represents a TypedDict definition.  TypedDicts use the class based
+// syntax unless a key isn't a valid identifier, in which case the functional
+// syntax is used:
+//
+//	Reactions = TypedDict("Reactions", {"+1": int})
+type TypedDict struct {
+	Name   string
+	Fields []Field
+}
+
+func (t TypedDict) String() string {
+	functional := false
+	for _, f := range t.Fields {
+		if f.Name != f.Key {
+			functional = true
+		}
+	}
+
+	if !functional {
+		return Class{Name: t.Name, Base: "TypedDict", Fields: t.Fields}.String()
+	}
+
+	str := strings.Builder{}
+	_, _ = str.WriteString(fmt.Sprintf("%s = TypedDict(\n%s%s,\n%s{\n", t.Name, indent, strconv.Quote(t.Name), indent))
+	for _, f := range t.Fields {
+		_, _ = str.WriteString(fmt.Sprintf("%s%s%s: %s,\n", indent, indent, strconv.Quote(f.Key), f.Type))
+	}
+	_, _ = str.WriteString(indent + "},\n)")
+	return str.String()
+}
+
+// Enum represents an enum.Enum subclass with a member for each value.
+type Enum struct {
+	Name string
+	// Base is the mixin type for the enum's values, eg. "str".
+	Base    string
+	Members []EnumMember
+}
+
+// EnumMember is a single named value within an Enum.
+type EnumMember struct {
+	Name  string
+	Value interface{}
+}
+
+func (e Enum) String() string {
+	str := strings.Builder{}
+	_, _ = str.WriteString(fmt.Sprintf("class %s(%s, Enum):\n", e.Name, e.Base))
+	for n, m := range e.Members {
+		_, _ = str.WriteString(fmt.Sprintf("%s%s = %s", indent, m.Name, literal(m.Value)))
+		if n < len(e.Members)-1 {
+			_, _ = str.WriteString("\n")
+		}
+	}
+	return str.String()
+}
+
+// literal returns the Python literal for a scalar value.
+func literal(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "None"
+	case string:
+		return strconv.Quote(t)
+	case bool:
+		if t {
+			return "True"
+		}
+		return "False"
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
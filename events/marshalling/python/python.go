@@ -0,0 +1,505 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/events/marshalling"
+)
+
+// Style represents the kind of class generated for each struct.
+type Style int
+
+const (
+	// StyleTypedDict generates a TypedDict for each struct, with NotRequired
+	// annotations for optional fields.  Both are imported from
+	// typing_extensions, as NotRequired requires Python 3.11 within typing.
+	StyleTypedDict Style = iota
+	// StylePydantic generates a pydantic v2 BaseModel for each struct.
+	StylePydantic
+)
+
+// EnumStyle represents how enums of scalar values are generated.
+type EnumStyle int
+
+const (
+	// EnumLiteral generates a Literal[...] type alias for enums.
+	EnumLiteral EnumStyle = iota
+	// EnumClass generates an enum.Enum subclass for enums.
+	EnumClass
+)
+
+// Options configures the generated Python types.
+type Options struct {
+	Style Style
+	Enums EnumStyle
+}
+
+var (
+	// keywords lists Python's reserved words, which can't be used as
+	// attribute names.
+	keywords = map[string]bool{
+		"False": true, "None": true, "True": true, "and": true, "as": true,
+		"assert": true, "async": true, "await": true, "break": true, "class": true,
+		"continue": true, "def": true, "del": true, "elif": true, "else": true,
+		"except": true, "finally": true, "for": true, "from": true, "global": true,
+		"if": true, "import": true, "in": true, "is": true, "lambda": true,
+		"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+		"return": true, "try": true, "while": true, "with": true, "yield": true,
+	}
+)
+
+func MarshalString(cuestr string, o Options) (string, error) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", cuestr)
+	if err != nil {
+		return "", fmt.Errorf("error generating inst: %w", err)
+	}
+	return MarshalCueValue(inst.Value(), o)
+}
+
+// MarshalCueValue returns Python type definitions given a cue value.
+func MarshalCueValue(v cue.Value, o Options) (string, error) {
+	return marshalling.Marshal(context.Background(), v, generator{o: o})
+}
+
+type generator struct {
+	o Options
+}
+
+func (g generator) AST(ctx context.Context, ast []marshalling.ParsedAST) ([]marshalling.Expr, error) {
+	return GenerateExprs(ctx, ast, g.o)
+}
+
+// GenerateExprs generates a Python definition for each top-level identifier in
+// the parsed AST, preceded by the imports the definitions require.  Nested
+// structs and enums are hoisted into their own named classes, prefixed with the
+// name of their parent.  Classes are always defined before they are used.
+func GenerateExprs(ctx context.Context, ast []marshalling.ParsedAST, o Options) ([]marshalling.Expr, error) {
+	s := &scope{
+		o:        o,
+		names:    map[string]bool{},
+		refs:     map[string]string{},
+		imports:  Imports{},
+		items:    map[string]marshalling.ParsedAST{},
+		declared: map[string]bool{},
+	}
+
+	for _, item := range ast {
+		s.refs[item.Name()] = s.name(className(item.Name()))
+		s.items[item.Name()] = item
+	}
+
+	for _, item := range ast {
+		if err := s.declareRef(item.Name()); err != nil {
+			return nil, err
+		}
+	}
+
+	exprs := []marshalling.Expr{}
+	if len(s.imports) > 0 {
+		exprs = append(exprs, s.imports)
+	}
+	for _, decl := range s.decls {
+		if len(exprs) > 0 {
+			exprs = append(exprs, Lit{Value: "\n\n\n"})
+		}
+		exprs = append(exprs, decl)
+	}
+	return exprs, nil
+}
+
+// scope records every definition generated for a set of parsed ASTs.
+type scope struct {
+	o       Options
+	decls   []marshalling.Expr
+	names   map[string]bool
+	refs    map[string]string
+	imports Imports
+
+	// items stores each top-level item by its label, and declared records
+	// the top-level items which have been declared.
+	items    map[string]marshalling.ParsedAST
+	declared map[string]bool
+}
+
+// use records an import for the given module.
+func (s *scope) use(module string, names ...string) {
+	for _, n := range names {
+		found := false
+		for _, existing := range s.imports[module] {
+			if existing == n {
+				found = true
+			}
+		}
+		if !found {
+			s.imports[module] = append(s.imports[module], n)
+		}
+	}
+}
+
+// typing imports the given names from the typing module, returning the
+// first name for convenience.
+func (s *scope) typing(names ...string) string {
+	s.use("typing", names...)
+	return names[0]
+}
+
+// declareRef declares the top-level item with the given label, if it hasn't
+// already been declared.  This ensures that top-level items are declared
+// before any class which refers to them.
+func (s *scope) declareRef(label string) error {
+	item, ok := s.items[label]
+	if !ok || s.declared[label] {
+		return nil
+	}
+	s.declared[label] = true
+	return s.declare(item)
+}
+
+// declare adds a top-level definition for the given item.
+func (s *scope) declare(item marshalling.ParsedAST) error {
+	name := s.refs[item.Name()]
+
+	switch parsed := item.(type) {
+	case *marshalling.ParsedStruct:
+		_, err := s.structType(name, parsed)
+		return err
+	case *marshalling.ParsedEnum:
+		typ, err := s.enumType(name, parsed)
+		if err != nil {
+			return err
+		}
+		if typ != name {
+			s.decls = append(s.decls, Alias{Name: name, Type: typ})
+		}
+		return nil
+	default:
+		typ, err := s.typeOf(name, item)
+		if err != nil {
+			return err
+		}
+		s.decls = append(s.decls, Alias{Name: name, Type: typ})
+		return nil
+	}
+}
+
+// typeOf returns the Python type annotation for the given parsed AST.
+func (s *scope) typeOf(name string, item marshalling.ParsedAST) (string, error) {
+	switch parsed := item.(type) {
+	case *marshalling.ParsedStructField:
+		return s.typeOf(name, parsed.ParsedAST)
+	case *marshalling.ParsedStruct:
+		if len(parsed.Members) == 0 {
+			return s.typing("Dict", "Any") + "[str, Any]", nil
+		}
+		return s.structType(s.name(name), parsed)
+	case *marshalling.ParsedEnum:
+		return s.enumType(s.name(name), parsed)
+	case *marshalling.ParsedArray:
+		elems := []string{}
+		for _, m := range parsed.Members {
+			typ, err := s.typeOf(name+"Item", m)
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, typ)
+		}
+		if len(elems) == 0 {
+			elems = []string{s.typing("Any")}
+		}
+		return fmt.Sprintf("%s[%s]", s.typing("List"), s.union(elems)), nil
+	case *marshalling.ParsedIdent:
+		if ref, ok := s.refs[parsed.Ident.Name]; ok {
+			if err := s.declareRef(parsed.Ident.Name); err != nil {
+				return "", err
+			}
+			return ref, nil
+		}
+		return s.identToPython(parsed.Ident.Name), nil
+	case *marshalling.ParsedScalar:
+		if parsed.Value == nil {
+			return "None", nil
+		}
+		if _, ok := parsed.Value.(float64); ok {
+			// Literal can't hold floats, per PEP 586.
+			return "float", nil
+		}
+		return fmt.Sprintf("%s[%s]", s.typing("Literal"), literal(parsed.Value)), nil
+	}
+	return "", fmt.Errorf("unknown parsed ast: %T", item)
+}
+
+// structType defines a class for the given struct, returning the class name.
+// Any nested classes are defined before the struct's class.
+func (s *scope) structType(name string, p *marshalling.ParsedStruct) (string, error) {
+	fields := []Field{}
+	seen := map[string]bool{}
+	for _, member := range p.Members {
+		typ, err := s.typeOf(name+className(member.Name()), member.ParsedAST)
+		if err != nil {
+			return "", err
+		}
+
+		base := attrName(member.Name())
+		attr := base
+		for i := 2; seen[attr]; i++ {
+			attr = fmt.Sprintf("%s_%d", base, i)
+		}
+		seen[attr] = true
+
+		field := Field{Name: attr, Key: member.Name(), Type: typ}
+		switch s.o.Style {
+		case StylePydantic:
+			if member.Optional {
+				field.Type = fmt.Sprintf("%s[%s]", s.typing("Optional"), typ)
+				field.Default = "None"
+			}
+			if attr != member.Name() {
+				s.use("pydantic", "Field")
+				def := field.Default
+				if def != "" {
+					def = "default=" + def + ", "
+				}
+				field.Default = fmt.Sprintf("Field(%salias=%s)", def, literal(member.Name()))
+			}
+		default:
+			if member.Optional {
+				s.use("typing_extensions", "NotRequired")
+				field.Type = fmt.Sprintf("NotRequired[%s]", typ)
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	switch s.o.Style {
+	case StylePydantic:
+		s.use("pydantic", "BaseModel")
+		s.decls = append(s.decls, Class{Name: name, Base: "BaseModel", Fields: fields})
+	default:
+		s.use("typing_extensions", "TypedDict")
+		s.decls = append(s.decls, TypedDict{Name: name, Fields: fields})
+	}
+	return name, nil
+}
+
+// enumType returns the type for an enum.  Enums of strings or integers are
+// generated using the configured EnumStyle;  any other enum is returned as a
+// union of each member's type.  Float members are typed as float, as Literal
+// can't hold floats.
+func (s *scope) enumType(name string, p *marshalling.ParsedEnum) (string, error) {
+	var base string
+	for _, m := range p.Members {
+		scalar, ok := m.(*marshalling.ParsedScalar)
+		if !ok {
+			base = ""
+			break
+		}
+		var typ string
+		switch scalar.Value.(type) {
+		case string:
+			typ = "str"
+		case int, int64:
+			typ = "int"
+		}
+		if typ == "" || (base != "" && typ != base) {
+			base = ""
+			break
+		}
+		base = typ
+	}
+
+	if base == "" || s.o.Enums == EnumLiteral {
+		types := []string{}
+		for n, m := range p.Members {
+			typ, err := s.typeOf(fmt.Sprintf("%s%d", name, n), m)
+			if err != nil {
+				return "", err
+			}
+			types = append(types, typ)
+		}
+		return s.union(types), nil
+	}
+
+	s.use("enum", "Enum")
+	enum := Enum{Name: name, Base: base}
+	seen := map[string]bool{}
+	for _, m := range p.Members {
+		value := m.(*marshalling.ParsedScalar).Value
+		member := strings.ToUpper(snakeCase(fmt.Sprintf("%v", value)))
+		if member == "" || unicode.IsDigit([]rune(member)[0]) {
+			member = "VALUE_" + member
+		}
+		unique := member
+		for i := 2; seen[unique]; i++ {
+			unique = fmt.Sprintf("%s_%d", member, i)
+		}
+		seen[unique] = true
+		enum.Members = append(enum.Members, EnumMember{Name: unique, Value: value})
+	}
+	s.decls = append(s.decls, enum)
+	return name, nil
+}
+
+// union combines the given types into a single annotation.  Literal members
+// are combined into a single Literal, and None members make the union
+// Optional.
+func (s *scope) union(types []string) string {
+	literals := []string{}
+	others := []string{}
+	nullable := false
+	seen := map[string]bool{}
+
+	for _, t := range types {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+
+		switch {
+		case t == "None":
+			nullable = true
+		case strings.HasPrefix(t, "Literal[") && !strings.Contains(t, "None"):
+			literals = append(literals, strings.TrimSuffix(strings.TrimPrefix(t, "Literal["), "]"))
+		default:
+			others = append(others, t)
+		}
+	}
+
+	// Integers, including integer literals, can be held within floats.
+	if seen["float"] {
+		filtered := []string{}
+		for _, t := range others {
+			if t != "int" {
+				filtered = append(filtered, t)
+			}
+		}
+		others = filtered
+
+		filtered = []string{}
+		for _, l := range literals {
+			if _, err := strconv.Atoi(l); err != nil {
+				filtered = append(filtered, l)
+			}
+		}
+		literals = filtered
+	}
+
+	if len(literals) > 0 {
+		others = append([]string{fmt.Sprintf("Literal[%s]", strings.Join(literals, ", "))}, others...)
+	}
+
+	var typ string
+	switch len(others) {
+	case 0:
+		return "None"
+	case 1:
+		typ = others[0]
+	default:
+		typ = fmt.Sprintf("%s[%s]", s.typing("Union"), strings.Join(others, ", "))
+	}
+
+	if nullable {
+		return fmt.Sprintf("%s[%s]", s.typing("Optional"), typ)
+	}
+	return typ
+}
+
+// name returns a unique class name within the scope.
+func (s *scope) name(name string) string {
+	if name == "" {
+		name = "Type"
+	}
+	unique := name
+	for i := 2; s.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	s.names[unique] = true
+	return unique
+}
+
+// identToPython returns Python type names from a given cue type name.
+func (s *scope) identToPython(name string) string {
+	switch name {
+	case "string":
+		return "str"
+	case "bool":
+		return "bool"
+	case "bytes":
+		return "bytes"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "rune":
+		return "int"
+	case "float", "float32", "float64", "number":
+		return "float"
+	case "[...]":
+		return s.typing("List", "Any") + "[Any]"
+	case "{...}":
+		return s.typing("Dict", "Any") + "[str, Any]"
+	default:
+		return s.typing("Any")
+	}
+}
+
+// className returns a Python class name for the given cue label, eg.
+// "pull_request" becomes "PullRequest".
+func className(label string) string {
+	parts := strings.FieldsFunc(label, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	str := strings.Builder{}
+	for _, p := range parts {
+		runes := []rune(p)
+		runes[0] = unicode.ToUpper(runes[0])
+		_, _ = str.WriteString(string(runes))
+	}
+	name := str.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "T" + name
+	}
+	return name
+}
+
+// attrName returns a valid Python attribute name for the given key.
+func attrName(key string) string {
+	if isIdentifier(key) && !keywords[key] {
+		return key
+	}
+
+	name := snakeCase(key)
+	switch {
+	case keywords[name]:
+		return name + "_"
+	case strings.HasPrefix(key, "+"):
+		name = "plus_" + name
+	case strings.HasPrefix(key, "-"):
+		name = "minus_" + name
+	}
+	if !isIdentifier(name) {
+		name = "field_" + name
+	}
+	return name
+}
+
+// isIdentifier returns whether the given string is a valid Python identifier.
+func isIdentifier(s string) bool {
+	for n, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (n == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// snakeCase replaces any non-alphanumeric characters in the given string
+// with underscores.
+func snakeCase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,59 @@
+package python
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonGeneration(t *testing.T) {
+	entries, err := os.ReadDir("./testdata")
+	require.NoError(t, err)
+
+	// Each cue file is generated using every option set, and compared to the
+	// file with the given suffix.
+	variants := map[string]Options{
+		".py":          {Style: StyleTypedDict, Enums: EnumLiteral},
+		".pydantic.py": {Style: StylePydantic, Enums: EnumClass},
+	}
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".cue") {
+			continue
+		}
+
+		name := e.Name()
+		contents, err := ioutil.ReadFile(path.Join("./testdata", name))
+		require.NoError(t, err)
+
+		for suffix, o := range variants {
+			t.Run(name+suffix, func(t *testing.T) {
+				expected, err := ioutil.ReadFile(path.Join("./testdata", strings.ReplaceAll(name, ".cue", suffix)))
+				require.NoError(t, err)
+
+				actual, err := MarshalString(string(contents), o)
+				require.NoError(t, err)
+				require.EqualValues(t, strings.TrimSpace(string(expected)), actual)
+			})
+		}
+	}
+}
+
+func TestAttrName(t *testing.T) {
+	tests := map[string]string{
+		"name":       "name",
+		"_id":        "_id",
+		"created-at": "created_at",
+		"from":       "from_",
+		"+1":         "plus_1",
+		"-1":         "minus_1",
+		"2fa":        "field_2fa",
+	}
+	for input, expected := range tests {
+		require.Equal(t, expected, attrName(input), input)
+	}
+}
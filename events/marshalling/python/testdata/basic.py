@@ -0,0 +1,59 @@
+from typing import List, Literal, Union
+
+from typing_extensions import NotRequired, TypedDict
+
+
+Status = Literal["open", "closed"]
+
+
+Some = TypedDict(
+    "Some",
+    {
+        "with": str,
+    },
+)
+
+
+class EventDataFriendsItem(TypedDict):
+    id: int
+    name: str
+
+
+class EventDataNestedItem(TypedDict):
+    id: int
+    heyy: Literal["what", "do"]
+
+
+class EventData(TypedDict):
+    action: Literal["push", "pull", "rebase"]
+    status: Status
+    number: int
+    static: Literal["lol this is content"]
+    optionalStatic: NotRequired[Literal["some opt content"]]
+    staticNumber: Literal[1]
+    staticBool: NotRequired[Literal[True]]
+    enabled: bool
+    numeric: float
+    ratio: float
+    threshold: Union[Literal["auto"], float]
+    mixed: Union[str, int]
+    friends: List[EventDataFriendsItem]
+    nested: List[EventDataNestedItem]
+
+
+EventAllow = TypedDict(
+    "EventAllow",
+    {
+        "with": str,
+        "included": bool,
+    },
+)
+
+
+class Event(TypedDict):
+    name: str
+    data: EventData
+    allow: EventAllow
+    anotherList: List[Union[float, str]]
+    numberList: List[float]
+    fixedNumber: List[float]
@@ -0,0 +1,65 @@
+from enum import Enum
+from typing import List, Literal, Optional, Union
+
+from pydantic import BaseModel, Field
+
+
+class Status(str, Enum):
+    OPEN = "open"
+    CLOSED = "closed"
+
+
+class Some(BaseModel):
+    with_: str = Field(alias="with")
+
+
+class EventDataAction(str, Enum):
+    PUSH = "push"
+    PULL = "pull"
+    REBASE = "rebase"
+
+
+class EventDataFriendsItem(BaseModel):
+    id: int
+    name: str
+
+
+class EventDataNestedItemHeyy(str, Enum):
+    WHAT = "what"
+    DO = "do"
+
+
+class EventDataNestedItem(BaseModel):
+    id: int
+    heyy: EventDataNestedItemHeyy
+
+
+class EventData(BaseModel):
+    action: EventDataAction
+    status: Status
+    number: int
+    static: Literal["lol this is content"]
+    optionalStatic: Optional[Literal["some opt content"]] = None
+    staticNumber: Literal[1]
+    staticBool: Optional[Literal[True]] = None
+    enabled: bool
+    numeric: float
+    ratio: float
+    threshold: Union[Literal["auto"], float]
+    mixed: Union[str, int]
+    friends: List[EventDataFriendsItem]
+    nested: List[EventDataNestedItem]
+
+
+class EventAllow(BaseModel):
+    with_: str = Field(alias="with")
+    included: bool
+
+
+class Event(BaseModel):
+    name: str
+    data: EventData
+    allow: EventAllow
+    anotherList: List[Union[float, str]]
+    numberList: List[float]
+    fixedNumber: List[float]
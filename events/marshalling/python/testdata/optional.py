@@ -0,0 +1,21 @@
+from typing import Any, Dict, List, Literal, Optional
+
+from typing_extensions import NotRequired, TypedDict
+
+
+class UserAddress(TypedDict):
+    city: str
+    zip_code: str
+
+
+class User(TypedDict):
+    id: int
+    email: NotRequired[str]
+    avatar: Optional[str]
+    tags: NotRequired[List[str]]
+    metadata: NotRequired[Dict[str, Any]]
+    address: NotRequired[UserAddress]
+    labels: List[Any]
+
+
+Level = Literal[1, 2, 3]
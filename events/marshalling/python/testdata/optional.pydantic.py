@@ -0,0 +1,25 @@
+from enum import Enum
+from typing import Any, Dict, List, Optional
+
+from pydantic import BaseModel
+
+
+class UserAddress(BaseModel):
+    city: str
+    zip_code: str
+
+
+class User(BaseModel):
+    id: int
+    email: Optional[str] = None
+    avatar: Optional[str]
+    tags: Optional[List[str]] = None
+    metadata: Optional[Dict[str, Any]] = None
+    address: Optional[UserAddress] = None
+    labels: List[Any]
+
+
+class Level(int, Enum):
+    VALUE_1 = 1
+    VALUE_2 = 2
+    VALUE_3 = 3
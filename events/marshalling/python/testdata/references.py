@@ -0,0 +1,17 @@
+from typing import List
+
+from typing_extensions import NotRequired, TypedDict
+
+
+class Org(TypedDict):
+    name: str
+
+
+class User(TypedDict):
+    login: str
+    org: NotRequired[Org]
+
+
+class Event(TypedDict):
+    sender: User
+    users: List[User]
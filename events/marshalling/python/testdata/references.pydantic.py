@@ -0,0 +1,17 @@
+from typing import List, Optional
+
+from pydantic import BaseModel
+
+
+class Org(BaseModel):
+    name: str
+
+
+class User(BaseModel):
+    login: str
+    org: Optional[Org] = None
+
+
+class Event(BaseModel):
+    sender: User
+    users: List[User]
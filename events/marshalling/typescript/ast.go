@@ -84,6 +84,8 @@ type Scalar struct {
 
 func (s Scalar) String() string {
 	switch t := s.Value.(type) {
+	case nil:
+		return "null"
 	case string:
 		return strconv.Quote(t)
 	default:
@@ -295,10 +297,11 @@ func (b Binding) String() string {
 		_, _ = str.WriteString("{\n")
 
 		for _, v := range b.Members {
-			for i := 0; i <= b.IndentLevel; i++ {
-				_, _ = str.WriteString(indent)
+			prefix := strings.Repeat(indent, b.IndentLevel+1)
+			if kv, ok := v.(KeyValue); ok && len(kv.Doc) > 0 {
+				_, _ = str.WriteString(kv.Doc.Indent(prefix))
 			}
-			_, _ = str.WriteString(fmt.Sprintf("%s%s\n", v.String(), term))
+			_, _ = str.WriteString(fmt.Sprintf("%s%s%s\n", prefix, v.String(), term))
 		}
 
 		// Add indents to the terminator, eg.
@@ -323,6 +326,8 @@ type KeyValue struct {
 	Key      string
 	Value    marshalling.Expr
 	Optional bool
+	// Doc is the optional JSDoc comment added above the key.
+	Doc JSDoc
 }
 
 func (kv KeyValue) String() string {
@@ -337,6 +342,26 @@ func (kv KeyValue) String() string {
 	return fmt.Sprintf("%s: %s", key, kv.Value.String())
 }
 
+// JSDoc represents the tags within a JSDoc comment, eg. "@minimum 0".
+type JSDoc []string
+
+// Indent returns the comment with each line prefixed by the given indent.
+// Single tags are written on one line:
+//
+//	/** @minimum 0 */
+func (d JSDoc) Indent(prefix string) string {
+	if len(d) == 1 {
+		return fmt.Sprintf("%s/** %s */\n", prefix, d[0])
+	}
+	str := strings.Builder{}
+	_, _ = str.WriteString(prefix + "/**\n")
+	for _, line := range d {
+		_, _ = str.WriteString(fmt.Sprintf("%s * %s\n", prefix, line))
+	}
+	_, _ = str.WriteString(prefix + " */\n")
+	return str.String()
+}
+
 // An Enum is an ADT - a union type within Cue.  We special-case enums because
 // of typescript limitations. A pure `enum Foo {...}` in typescript isn't that
 // fun to use;  it's recommended by many to create an Object containing the enum
@@ -26,6 +26,10 @@ export interface Event {
   data: {
     action: Action;
     status: Status;
+    /**
+     * @minimum 0
+     * @maximum 10
+     */
     number: number;
     static: "lol this is content";
     optionalStatic?: "some opt content";
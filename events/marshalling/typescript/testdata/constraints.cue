@@ -0,0 +1,12 @@
+import "strings"
+
+#Account: {
+	username: string & =~"^[a-z0-9_]+$" & !~"^admin" & strings.MinRunes(3) & strings.MaxRunes(16)
+	age:      uint8 & >=13
+	score:    number & >0 & <1
+	balance:  int & >=-100 & <=100 | *0
+	role:     string & !="root"
+	nested: {
+		count: uint & <=10
+	}
+}
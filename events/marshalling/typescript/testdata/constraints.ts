@@ -0,0 +1,33 @@
+export interface Account {
+  /**
+   * @pattern ^[a-z0-9_]+$
+   * @notPattern ^admin
+   * @minLength 3
+   * @maxLength 16
+   */
+  username: string;
+  /**
+   * @format uint8
+   * @minimum 13
+   */
+  age: number;
+  /**
+   * @exclusiveMinimum 0
+   * @exclusiveMaximum 1
+   */
+  score: number;
+  /**
+   * @minimum -100
+   * @maximum 100
+   */
+  balance: number;
+  /** @not "root" */
+  role: string;
+  nested: {
+    /**
+     * @minimum 0
+     * @maximum 10
+     */
+    count: number;
+  };
+};
@@ -0,0 +1,13 @@
+#Event: {
+	sender: #User
+	users: [...#User]
+}
+
+#User: {
+	login: string
+	org?:  #Org
+}
+
+#Org: {
+	name: string
+}
@@ -0,0 +1,13 @@
+export interface Event {
+  sender: User;
+  users: Array<User>;
+};
+
+export interface User {
+  login: string;
+  org?: Org;
+};
+
+export interface Org {
+  name: string;
+};
@@ -3,6 +3,7 @@ package typescript
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"cuelang.org/go/cue"
@@ -11,6 +12,8 @@ import (
 
 var (
 	ctxDepth = "indent"
+
+	nonAlphaRegexp = regexp.MustCompile(`[^\w]|_`)
 )
 
 func MarshalString(cuestr string) (string, error) {
@@ -96,6 +99,10 @@ func generateEnum(ctx context.Context, e *marshalling.ParsedEnum) (marshalling.E
 	if depth(ctx) > 1 {
 		simple = true
 		for _, m := range e.Members {
+			if scalar, ok := m.(*marshalling.ParsedScalar); ok && scalar.Value == nil {
+				// Nullable types, eg. `string | null`, are still simple.
+				continue
+			}
 			if m.Kind() != marshalling.KindIdent {
 				simple = false
 			}
@@ -146,11 +153,15 @@ func generateStruct(ctx context.Context, s *marshalling.ParsedStruct) ([]marshal
 				})
 			default:
 				// This is a top-level field.
-				binding.Members = append(binding.Members, KeyValue{
+				kv := KeyValue{
 					Key:      member.Name(),
 					Value:    field,
 					Optional: member.Optional,
-				})
+				}
+				if ident, ok := member.ParsedAST.(*marshalling.ParsedIdent); ok {
+					kv.Doc = constraintDoc(ident)
+				}
+				binding.Members = append(binding.Members, kv)
 			}
 		}
 	}
@@ -241,7 +252,10 @@ func identToTS(name string) string {
 	switch name {
 	case "bool":
 		return "boolean"
-	case "float", "int", "number":
+	case "float", "int", "number",
+		"int8", "int16", "int32", "int64", "int128",
+		"uint8", "uint16", "uint32", "uint64", "uint128",
+		"float32", "float64":
 		return "number"
 	case "_":
 		return "unknown"
@@ -250,13 +264,53 @@ func identToTS(name string) string {
 	case "{...}":
 		return "{ [key: string]: unknown }"
 	default:
-		return name
+		// References to definitions use the name of the exported type.
+		return strings.ReplaceAll(name, "#", "")
+	}
+}
+
+// constraintDoc returns JSDoc tags for each constraint on the ident, using
+// the JSON schema keyword names where one exists.
+func constraintDoc(ident *marshalling.ParsedIdent) JSDoc {
+	doc := JSDoc{}
+	for _, c := range ident.Constraints {
+		value := Scalar{Value: c.Value}.String()
+		switch c.Kind {
+		case marshalling.ConstraintMin:
+			if c.Exclusive {
+				doc = append(doc, "@exclusiveMinimum "+value)
+				continue
+			}
+			doc = append(doc, "@minimum "+value)
+		case marshalling.ConstraintMax:
+			if c.Exclusive {
+				doc = append(doc, "@exclusiveMaximum "+value)
+				continue
+			}
+			doc = append(doc, "@maximum "+value)
+		case marshalling.ConstraintRegex:
+			doc = append(doc, "@pattern "+Scalar{Value: c.Value}.Unquoted())
+		case marshalling.ConstraintNotRegex:
+			doc = append(doc, "@notPattern "+Scalar{Value: c.Value}.Unquoted())
+		case marshalling.ConstraintMinLength:
+			doc = append(doc, "@minLength "+value)
+		case marshalling.ConstraintMaxLength:
+			doc = append(doc, "@maxLength "+value)
+		case marshalling.ConstraintWidth:
+			doc = append(doc, "@format "+ident.Ident.Name)
+		case marshalling.ConstraintNotEqual:
+			doc = append(doc, "@not "+value)
+		}
 	}
+	return doc
 }
 
+// title returns the exported type name for a label, eg. "user_report" becomes
+// "UserReport".
 func title(s string) string {
-	s = strings.ReplaceAll(s, "#", "")
-	return strings.Title(s)
+	s = nonAlphaRegexp.ReplaceAllString(s, " ")
+	s = strings.Title(s)
+	return strings.ReplaceAll(s, " ", "")
 }
 
 // indentLevel returns the current indent level from the context.  This is
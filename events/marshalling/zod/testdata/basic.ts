@@ -0,0 +1,41 @@
+import { z } from "zod";
+
+export const Status = z.enum(["open", "closed"]);
+export type Status = z.infer<typeof Status>;
+
+export const Some = z.object({
+  with: z.string(),
+});
+export type Some = z.infer<typeof Some>;
+
+export const Event = z.object({
+  name: z.string(),
+  data: z.object({
+    action: z.enum(["push", "pull", "rebase"]),
+    status: Status,
+    number: z.number().int().nonnegative().max(10),
+    static: z.literal("lol this is content"),
+    optionalStatic: z.literal("some opt content").optional(),
+    staticNumber: z.literal(1),
+    staticBool: z.literal(true).optional(),
+    enabled: z.boolean(),
+    numeric: z.number(),
+    mixed: z.union([z.string(), z.number().int()]),
+    friends: z.array(z.object({
+      id: z.number().int(),
+      name: z.string(),
+    })),
+    nested: z.array(z.object({
+      id: z.number().int(),
+      heyy: z.enum(["what", "do"]),
+    })),
+  }),
+  allow: z.object({
+    with: z.string(),
+    included: z.boolean(),
+  }),
+  anotherList: z.array(z.union([z.number().int(), z.number(), z.string()])),
+  numberList: z.array(z.union([z.number().int(), z.number()])),
+  fixedNumber: z.array(z.union([z.literal(1), z.literal(2), z.literal(3.14159)])),
+});
+export type Event = z.infer<typeof Event>;
@@ -0,0 +1,16 @@
+import "strings"
+
+#Payment: {
+	amount:    uint & <=10
+	quantity:  int & >=1 & <100 | *1
+	ratio:     float & >0 & <=1
+	balance:   number & <0
+	currency:  "usd" | "eur" | *"usd"
+	note:      string | null
+	slug:      string & =~"^[a-z0-9-]+$" & strings.MinRunes(3) & strings.MaxRunes(64)
+	path:      string & =~"^/repos/[^/]+$"
+	name:      string & !~"^admin" & !="root"
+	tags?:     [...string]
+	metadata:  {...}
+	"+1":      int
+}
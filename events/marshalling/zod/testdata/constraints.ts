@@ -0,0 +1,17 @@
+import { z } from "zod";
+
+export const Payment = z.object({
+  amount: z.number().int().nonnegative().max(10),
+  quantity: z.number().int().min(1).lt(100).default(1),
+  ratio: z.number().positive().max(1),
+  balance: z.number().negative(),
+  currency: z.enum(["usd", "eur"]).default("usd"),
+  note: z.string().nullable(),
+  slug: z.string().regex(/^[a-z0-9-]+$/).min(3).max(64),
+  path: z.string().regex(/^\/repos\/[^\/]+$/),
+  name: z.string().refine((s) => !/^admin/.test(s)).refine((s) => s !== "root"),
+  tags: z.array(z.string()).optional(),
+  metadata: z.record(z.unknown()),
+  "+1": z.number().int(),
+});
+export type Payment = z.infer<typeof Payment>;
@@ -0,0 +1,380 @@
+package zod
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/events/marshalling"
+)
+
+const (
+	indent = "  "
+
+	// header is the import required by every generated schema.
+	header = `import { z } from "zod";`
+)
+
+var (
+	alphanumeric = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+)
+
+func MarshalString(cuestr string) (string, error) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", cuestr)
+	if err != nil {
+		return "", fmt.Errorf("error generating inst: %w", err)
+	}
+	return MarshalCueValue(inst.Value())
+}
+
+// MarshalCueValue returns zod schemas, plus inferred TypeScript types for each
+// schema, given a cue value.
+func MarshalCueValue(v cue.Value) (string, error) {
+	return marshalling.Marshal(context.Background(), v, generator{})
+}
+
+type generator struct{}
+
+func (g generator) AST(ctx context.Context, ast []marshalling.ParsedAST) ([]marshalling.Expr, error) {
+	return GenerateExprs(ctx, ast)
+}
+
+// GenerateExprs generates an exported zod schema and an inferred type for each
+// top-level identifier in the parsed AST:
+//
+//	export const Event = z.object({ ... });
+//	export type Event = z.infer<typeof Event>;
+//
+// Schemas are ordered so that any schema referenced by another is declared
+// first.
+func GenerateExprs(ctx context.Context, ast []marshalling.ParsedAST) ([]marshalling.Expr, error) {
+	refs := map[string]string{}
+	for _, item := range ast {
+		refs[item.Name()] = name(item.Name())
+	}
+
+	schemas := make([]Schema, len(ast))
+	for n, item := range ast {
+		s := &scope{refs: refs, used: map[string]bool{}}
+		value, err := s.schema(item, 0)
+		if err != nil {
+			return nil, err
+		}
+		schemas[n] = Schema{Name: refs[item.Name()], Value: value, deps: s.used}
+	}
+
+	exprs := []marshalling.Expr{marshalling.Lit{Value: header}}
+	declared := map[string]bool{}
+	var declare func(s Schema)
+	declare = func(s Schema) {
+		if declared[s.Name] {
+			return
+		}
+		declared[s.Name] = true
+		for _, dep := range schemas {
+			if s.deps[dep.Name] {
+				declare(dep)
+			}
+		}
+		exprs = append(exprs, marshalling.Lit{Value: "\n\n"}, s)
+	}
+	for _, s := range schemas {
+		declare(s)
+	}
+	return exprs, nil
+}
+
+// Schema is an exported zod schema along with its inferred type.
+type Schema struct {
+	Name  string
+	Value string
+
+	// deps records the other schemas referenced by this schema.
+	deps map[string]bool
+}
+
+func (s Schema) String() string {
+	return fmt.Sprintf(
+		"export const %s = %s;\nexport type %s = z.infer<typeof %s>;",
+		s.Name, s.Value, s.Name, s.Name,
+	)
+}
+
+// scope records the references to other top-level schemas when generating
+// a single schema.
+type scope struct {
+	refs map[string]string
+	used map[string]bool
+}
+
+// schema returns the zod schema for the given parsed AST, indented to the
+// given depth.  Scalar defaults are added via .default().
+func (s *scope) schema(item marshalling.ParsedAST, depth int) (string, error) {
+	result, err := s.schemaOf(item, depth)
+	if err != nil {
+		return "", err
+	}
+
+	var def interface{}
+	switch parsed := item.(type) {
+	case *marshalling.ParsedIdent:
+		def = parsed.Default
+	case *marshalling.ParsedEnum:
+		def = parsed.Default
+	}
+	if scalar, ok := def.(*marshalling.ParsedScalar); ok {
+		result += fmt.Sprintf(".default(%s)", literal(scalar.Value))
+	}
+	return result, nil
+}
+
+func (s *scope) schemaOf(item marshalling.ParsedAST, depth int) (string, error) {
+	switch parsed := item.(type) {
+	case *marshalling.ParsedStructField:
+		return s.schema(parsed.ParsedAST, depth)
+	case *marshalling.ParsedStruct:
+		return s.object(parsed, depth)
+	case *marshalling.ParsedEnum:
+		return s.enum(parsed, depth)
+	case *marshalling.ParsedArray:
+		members := []string{}
+		for _, m := range parsed.Members {
+			member, err := s.schema(m, depth)
+			if err != nil {
+				return "", err
+			}
+			members = append(members, member)
+		}
+		if len(members) == 0 {
+			members = []string{"z.unknown()"}
+		}
+		return fmt.Sprintf("z.array(%s)", union(members)), nil
+	case *marshalling.ParsedIdent:
+		return s.ident(parsed), nil
+	case *marshalling.ParsedScalar:
+		if parsed.Value == nil {
+			return "z.null()", nil
+		}
+		return fmt.Sprintf("z.literal(%s)", literal(parsed.Value)), nil
+	}
+	return "", fmt.Errorf("unknown parsed ast: %T", item)
+}
+
+func (s *scope) object(p *marshalling.ParsedStruct, depth int) (string, error) {
+	if len(p.Members) == 0 {
+		return "z.record(z.unknown())", nil
+	}
+
+	str := strings.Builder{}
+	_, _ = str.WriteString("z.object({\n")
+	for _, member := range p.Members {
+		value, err := s.schema(member.ParsedAST, depth+1)
+		if err != nil {
+			return "", err
+		}
+		if member.Optional {
+			value += ".optional()"
+		}
+
+		key := member.Name()
+		if !alphanumeric.MatchString(key) {
+			key = strconv.Quote(key)
+		}
+		_, _ = str.WriteString(fmt.Sprintf("%s%s: %s,\n", strings.Repeat(indent, depth+1), key, value))
+	}
+	_, _ = str.WriteString(strings.Repeat(indent, depth) + "})")
+	return str.String(), nil
+}
+
+// enum returns a z.enum for enums of strings, or a union of each member
+// otherwise.  Unions containing null are made nullable.
+func (s *scope) enum(p *marshalling.ParsedEnum, depth int) (string, error) {
+	strs := []string{}
+	for _, m := range p.Members {
+		if scalar, ok := m.(*marshalling.ParsedScalar); ok {
+			if str, ok := scalar.Value.(string); ok {
+				strs = append(strs, strconv.Quote(str))
+				continue
+			}
+		}
+		strs = nil
+		break
+	}
+	if len(strs) > 0 {
+		return fmt.Sprintf("z.enum([%s])", strings.Join(strs, ", ")), nil
+	}
+
+	members := []string{}
+	nullable := false
+	for _, m := range p.Members {
+		if scalar, ok := m.(*marshalling.ParsedScalar); ok && scalar.Value == nil {
+			nullable = true
+			continue
+		}
+		member, err := s.schema(m, depth)
+		if err != nil {
+			return "", err
+		}
+		members = append(members, member)
+	}
+
+	if len(members) == 0 {
+		return "z.null()", nil
+	}
+	result := union(members)
+	if nullable {
+		result += ".nullable()"
+	}
+	return result, nil
+}
+
+// ident returns the schema for a basic type, including any constraints and
+// defaults.
+func (s *scope) ident(p *marshalling.ParsedIdent) string {
+	name := p.Ident.Name
+	if ref, ok := s.refs[name]; ok {
+		s.used[ref] = true
+		return ref
+	}
+
+	var schema string
+	numeric := false
+	switch name {
+	case "string", "bytes":
+		schema = "z.string()"
+	case "bool":
+		schema = "z.boolean()"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "rune":
+		schema = "z.number().int()"
+		numeric = true
+	case "float", "float32", "float64", "number":
+		schema = "z.number()"
+		numeric = true
+	case "null":
+		schema = "z.null()"
+	case "[...]":
+		schema = "z.array(z.unknown())"
+	case "{...}":
+		schema = "z.record(z.unknown())"
+	default:
+		schema = "z.unknown()"
+	}
+
+	for _, c := range p.Constraints {
+		switch {
+		case numeric:
+			schema += bound(c)
+		case name == "string":
+			schema += check(c)
+		}
+	}
+	return schema
+}
+
+// check returns the zod method for a string constraint.  Lengths are counted
+// in runes by cue, and in UTF-16 code units by zod, which only differ for
+// characters outside of the basic multilingual plane.
+func check(c marshalling.Constraint) string {
+	switch c.Kind {
+	case marshalling.ConstraintRegex:
+		return fmt.Sprintf(".regex(%s)", regex(c.Value))
+	case marshalling.ConstraintNotRegex:
+		return fmt.Sprintf(".refine((s) => !%s.test(s))", regex(c.Value))
+	case marshalling.ConstraintMinLength:
+		return fmt.Sprintf(".min(%s)", literal(c.Value))
+	case marshalling.ConstraintMaxLength:
+		return fmt.Sprintf(".max(%s)", literal(c.Value))
+	case marshalling.ConstraintNotEqual:
+		return fmt.Sprintf(".refine((s) => s !== %s)", literal(c.Value))
+	}
+	return ""
+}
+
+// regex returns the JavaScript regular expression literal for a pattern.
+func regex(pattern interface{}) string {
+	str, _ := pattern.(string)
+	escaped := strings.Builder{}
+	for i := 0; i < len(str); i++ {
+		switch {
+		case str[i] == '\\' && i+1 < len(str):
+			// Keep escapes, including escaped slashes, as-is.
+			_, _ = escaped.WriteString(str[i : i+2])
+			i++
+		case str[i] == '/':
+			_, _ = escaped.WriteString(`\/`)
+		case str[i] == '\n':
+			_, _ = escaped.WriteString(`\n`)
+		default:
+			_ = escaped.WriteByte(str[i])
+		}
+	}
+	return "/" + escaped.String() + "/"
+}
+
+// bound returns the zod method for a numeric bound, using zod's named
+// methods when bounding by zero.
+func bound(c marshalling.Constraint) string {
+	value := literal(c.Value)
+	zero := value == "0"
+
+	switch {
+	case c.Kind == marshalling.ConstraintMin && zero && c.Exclusive:
+		return ".positive()"
+	case c.Kind == marshalling.ConstraintMin && zero:
+		return ".nonnegative()"
+	case c.Kind == marshalling.ConstraintMin && c.Exclusive:
+		return fmt.Sprintf(".gt(%s)", value)
+	case c.Kind == marshalling.ConstraintMin:
+		return fmt.Sprintf(".min(%s)", value)
+	case c.Kind == marshalling.ConstraintMax && zero && c.Exclusive:
+		return ".negative()"
+	case c.Kind == marshalling.ConstraintMax && zero:
+		return ".nonpositive()"
+	case c.Kind == marshalling.ConstraintMax && c.Exclusive:
+		return fmt.Sprintf(".lt(%s)", value)
+	case c.Kind == marshalling.ConstraintMax:
+		return fmt.Sprintf(".max(%s)", value)
+	}
+	return ""
+}
+
+// union returns a z.union of the given schemas, deduplicating members.
+func union(members []string) string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, m := range members {
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+		unique = append(unique, m)
+	}
+	if len(unique) == 1 {
+		return unique[0]
+	}
+	return fmt.Sprintf("z.union([%s])", strings.Join(unique, ", "))
+}
+
+// literal returns the JavaScript literal for a scalar value.
+func literal(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(t)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// name returns the exported identifier for a top-level cue label.
+func name(label string) string {
+	return strings.ReplaceAll(label, "#", "")
+}
@@ -0,0 +1,26 @@
+package zod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZodGeneration(t *testing.T) {
+	files, err := filepath.Glob("./testdata/*.cue")
+	require.NoError(t, err)
+
+	for _, f := range files {
+		cue, err := os.ReadFile(f)
+		require.NoError(t, err)
+		expected, err := os.ReadFile(strings.Replace(f, ".cue", ".ts", 1))
+		require.NoError(t, err)
+
+		actual, err := MarshalString(string(cue))
+		require.NoError(t, err)
+		require.EqualValues(t, strings.TrimSpace(string(expected)), actual, f)
+	}
+}
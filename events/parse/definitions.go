@@ -0,0 +1,224 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+)
+
+// source is a formatted event schema, along with every cue definition that
+// the schema references.
+type source struct {
+	// schema is the formatted schema, using references to definitions
+	// in place of their values.
+	schema string
+	// defs lists each definition the schema references, transitively, in
+	// the order they were found.
+	defs []definition
+}
+
+// definition is a single formatted top-level definition, eg. #GitHubUser.
+type definition struct {
+	name  string
+	value string
+}
+
+// String returns the schema followed by each referenced definition as
+// well-formed cue.
+func (s source) String() string {
+	str := strings.Builder{}
+	_, _ = str.WriteString(s.schema)
+	for _, d := range s.defs {
+		_, _ = str.WriteString(fmt.Sprintf("\n\n%s: %s", d.name, d.value))
+	}
+	return str.String()
+}
+
+// labelled returns the source with the schema assigned to the given label,
+// eg. "#event", so that the source can be compiled as a single instance.
+func (s source) labelled(label string) string {
+	return fmt.Sprintf("%s: %s", label, s)
+}
+
+// compile compiles the labelled source into a new cue value.
+func (s source) compile(label string) (cue.Value, error) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", s.labelled(label))
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("error wrapping schema with event name: %w", err)
+	}
+	return inst.Value(), nil
+}
+
+// formatSource formats the given schema, replacing any value which is a
+// top-level definition within root with a reference to that definition.  Each
+// definition referenced is collected, so that the returned source can be
+// compiled on its own.
+func formatSource(v cue.Value, root cue.Value, opts ...cue.Option) (source, error) {
+	s := source{}
+	seen := map[string]bool{}
+
+	schema, queue, err := formatReferences(v, root, "", seen, opts...)
+	if err != nil {
+		return s, err
+	}
+	s.schema = schema
+
+	// Walk each definition in turn, adding any further definitions that it
+	// references to the queue.
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		def := root.LookupPath(cue.MakePath(cue.Def(name)))
+		if !def.Exists() {
+			return s, fmt.Errorf("unable to find definition: %s", name)
+		}
+		value, refs, err := formatReferences(def, root, name, seen, opts...)
+		if err != nil {
+			return s, err
+		}
+		s.defs = append(s.defs, definition{name: name, value: value})
+		queue = append(queue, refs...)
+	}
+
+	return s, nil
+}
+
+// formatReferences formats the given value with references resolved, then
+// replaces each field whose value is equivalent to a top-level definition
+// with the definition's name.  The definition named self is never replaced,
+// so that a definition doesn't format as a reference to itself.
+//
+// This returns the formatted value and any referenced definitions which
+// haven't yet been seen, marking each as seen.
+func formatReferences(v cue.Value, root cue.Value, self string, seen map[string]bool, opts ...cue.Option) (string, []string, error) {
+	opts = append([]cue.Option{
+		cue.Docs(true),
+		cue.Optional(true),
+		cue.Definitions(true),
+		cue.ResolveReferences(true),
+	}, opts...)
+
+	syn, ok := v.Syntax(opts...).(ast.Expr)
+	if !ok {
+		return "", nil, fmt.Errorf("unable to format schema: %v", v)
+	}
+
+	r := referencer{root: root, self: self}
+	node := r.replace(v, syn)
+
+	refs := []string{}
+	ast.Walk(node, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || !strings.HasPrefix(ident.Name, "#") || seen[ident.Name] {
+			return true
+		}
+		// Ignore any definitions declared within the value itself.
+		if !root.LookupPath(cue.MakePath(cue.Def(ident.Name))).Exists() {
+			return true
+		}
+		seen[ident.Name] = true
+		refs = append(refs, ident.Name)
+		return true
+	}, nil)
+
+	formatted, err := formatNode(node)
+	return formatted, refs, err
+}
+
+// referencer replaces values with the name of the top-level definition they
+// are equivalent to.
+type referencer struct {
+	root cue.Value
+	self string
+}
+
+func (r referencer) replace(v cue.Value, node ast.Expr) ast.Expr {
+	if name := r.definition(v); name != "" {
+		ident := ast.NewIdent(name)
+		ast.SetComments(ident, ast.Comments(node))
+		return ident
+	}
+
+	switch n := node.(type) {
+	case *ast.StructLit:
+		fields := map[string]cue.Value{}
+		it, err := v.Fields(cue.Optional(true), cue.Definitions(true))
+		if err != nil {
+			return node
+		}
+		for it.Next() {
+			fields[it.Label()] = it.Value()
+		}
+		for _, elt := range n.Elts {
+			f, ok := elt.(*ast.Field)
+			if !ok {
+				continue
+			}
+			label, _, err := ast.LabelName(f.Label)
+			if err != nil {
+				continue
+			}
+			if fv, ok := fields[label]; ok {
+				f.Value = r.replace(fv, f.Value)
+			}
+		}
+	case *ast.ListLit:
+		if len(n.Elts) != 1 {
+			return node
+		}
+		ellipsis, ok := n.Elts[0].(*ast.Ellipsis)
+		if !ok || ellipsis.Type == nil {
+			return node
+		}
+		if elem := v.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() {
+			ellipsis.Type = r.replace(elem, ellipsis.Type)
+		}
+	}
+	return node
+}
+
+// definition returns the name of the top-level definition which the value
+// refers to, if the value is equivalent to the definition.
+func (r referencer) definition(v cue.Value) string {
+	for _, name := range references(v) {
+		if name == r.self {
+			continue
+		}
+		def := r.root.LookupPath(cue.MakePath(cue.Def(name)))
+		if !def.Exists() {
+			continue
+		}
+		// Only replace the value if it's identical to the definition, ie.
+		// it's not been extended or further constrained.
+		if def.Subsume(v) == nil && v.Subsume(def) == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// references returns the names of top-level definitions referred to by the
+// value or any of its conjuncts.
+func references(v cue.Value) []string {
+	names := []string{}
+	stack := []cue.Value{v}
+	for len(stack) > 0 {
+		item := stack[0]
+		stack = stack[1:]
+
+		if _, path := item.ReferencePath(); len(path.Selectors()) == 1 {
+			if sel := path.Selectors()[0]; sel.IsDefinition() {
+				names = append(names, sel.String())
+			}
+			continue
+		}
+		if op, vals := item.Expr(); op == cue.AndOp {
+			stack = append(stack, vals...)
+		}
+	}
+	return names
+}
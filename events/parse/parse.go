@@ -0,0 +1,390 @@
+package parse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/encoding/openapi"
+	"github.com/inngest/event-schemas/defs"
+	"github.com/inngest/event-schemas/events"
+	"github.com/inngest/event-schemas/events/marshalling/golang"
+	"github.com/inngest/event-schemas/events/marshalling/jsonschema"
+	"github.com/inngest/event-schemas/events/marshalling/python"
+	"github.com/inngest/event-schemas/events/marshalling/typescript"
+	"github.com/inngest/event-schemas/events/marshalling/zod"
+	"github.com/inngest/event-schemas/pkg/fakedata"
+)
+
+const (
+	serviceSeparator = "/"
+
+	typescriptEventName = "InngestEvent"
+
+	// defsDir is the directory within defs.FS containing event definitions.
+	defsDir = "cue.mod"
+
+	// includedDefFile is the file name used when including #Def alongside
+	// custom definitions.
+	includedDefFile = "inngest_def.cue"
+)
+
+var (
+	c = &openapi.Config{
+		PkgName: "",
+		Version: "3.0.0",
+	}
+	nonAlphaRegexp = regexp.MustCompile("[^\\w]|_")
+
+	// exampleTime is the time which the timestamps of generated examples
+	// precede.
+	exampleTime = time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Options configures how event definitions are loaded.
+type Options struct {
+	// Dir is the directory within the filesystem containing the cue
+	// definitions.  Defaults to the root of the filesystem.
+	Dir string
+
+	// Package is the cue package to load.  Defaults to every package within
+	// Dir.
+	Package string
+
+	// IncludeDef adds this module's #Def contract alongside the definitions,
+	// so that custom definitions may use or extend #Def without copying it.
+	// Definitions using this must belong to the "eventdefintions" package.
+	IncludeDef bool
+
+	// GenerateExamples generates a deterministic example event from the
+	// schema for each definition without examples, marking the event's
+	// examples as generated.
+	GenerateExamples bool
+}
+
+// ParseDefs evaluates all embeded cue files within defs/cue.mod, returning
+// parsed event information from the cue types.
+func ParseDefs(ctx context.Context) ([]events.Event, error) {
+	return Parse(ctx, defs.FS, Options{Dir: defsDir, GenerateExamples: true})
+}
+
+// ParseDir evaluates all cue files within the given directory on disk,
+// returning parsed event information from the cue types.
+func ParseDir(ctx context.Context, dir string, opts Options) ([]events.Event, error) {
+	return Parse(ctx, os.DirFS(dir), opts)
+}
+
+// Parse evaluates all cue files within the filesystem, returning parsed event
+// information from every value which defines an event schema, ie. every value
+// satisfying #Def.  Versions of each event are grouped together and sorted
+// from oldest to newest, and defining the same event name and version twice
+// is an error.
+func Parse(ctx context.Context, fsys fs.FS, opts Options) ([]events.Event, error) {
+	insts, err := instances(ctx, fsys, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	evts := []events.Event{}
+
+	for _, i := range insts {
+		// Iterate through each value within the instance (file) and parse
+		// the event.
+		e, err := walkDefinitions(ctx, i.Value(), i, opts)
+		if err != nil {
+			return nil, err
+		}
+		evts = append(evts, e...)
+	}
+
+	return events.GroupVersions(evts)
+}
+
+// instances parses all cue files within the filesystem, returning cue
+// Instances representing each package.
+func instances(ctx context.Context, fsys fs.FS, opts Options) ([]*cue.Instance, error) {
+	instances := []*cue.Instance{}
+
+	dir := path.Join("/", opts.Dir)
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "*"
+	}
+
+	r := &cue.Runtime{}
+	cfg := load.Config{
+		Overlay:    map[string]load.Source{},
+		Dir:        dir,
+		ModuleRoot: "/",
+		Package:    pkg,
+		Stdin:      bytes.NewBuffer(nil),
+	}
+
+	err := fs.WalkDir(fsys, ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || path.Ext(p) != ".cue" {
+			return nil
+		}
+
+		contents, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		cfg.Overlay[path.Join("/", p)] = load.FromBytes(contents)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeDef {
+		contents, err := defs.FS.ReadFile(path.Join(defsDir, "def.cue"))
+		if err != nil {
+			return nil, err
+		}
+		cfg.Overlay[path.Join(dir, includedDefFile)] = load.FromBytes(contents)
+	}
+
+	bis := load.Instances([]string{""}, &cfg)
+	for _, i := range bis {
+		if i.Err != nil {
+			return nil, fmt.Errorf("error loading instance: %w", i.Err)
+		}
+		if len(i.BuildFiles) == 0 {
+			// The loader may return an empty instance alongside each
+			// package;  there's nothing to parse.
+			continue
+		}
+
+		inst, err := r.Build(i)
+		if err != nil {
+			return nil, fmt.Errorf("error building instance: %w", err)
+		}
+
+		instances = append(instances, inst)
+	}
+
+	return instances, nil
+}
+
+// walkDefinitions walks through each definition within a Cue instance, finds
+// every definition that contains an event schema, then parses the event schema
+// from the Cue type definition.
+func walkDefinitions(ctx context.Context, v cue.Value, i *cue.Instance, opts Options) ([]events.Event, error) {
+	events := []events.Event{}
+
+	it, err := v.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	for it.Next() {
+		if it.IsDefinition() {
+			continue
+		}
+
+		val := it.Value()
+		// If the value has a field "schema", it's part of our definition.
+		if _, err := val.LookupField("schema"); err != nil {
+			continue
+		}
+
+		evt, err := gen(ctx, val, i, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, *evt)
+
+	}
+
+	return events, nil
+}
+
+// gen generates a new event given the event definition as a cue.Value.
+func gen(ctx context.Context, v cue.Value, i *cue.Instance, opts Options) (*events.Event, error) {
+	// If the value has a field "schema", it's part of our definition.
+	sf, err := v.LookupField("schema")
+	if err != nil {
+		return nil, fmt.Errorf("unable to find schema field")
+	}
+
+	// Format the schema along with every definition that it references, so
+	// that each generator is given a standalone cue source.
+	src, err := formatSource(sf.Value, i.Value(), cue.Attributes(true))
+	if err != nil {
+		return nil, fmt.Errorf("error formatting schema: %w", err)
+	}
+
+	schema, err := jsonschema.MarshalDefinitions(src.labelled("#event"), "event")
+	if err != nil {
+		return nil, err
+	}
+
+	name := cueString(sf.Value, "name")
+
+	// Marshal the typescript in an embedded event.
+	ts, err := genTypescript(src)
+	if err != nil {
+		return nil, err
+	}
+
+	goType, err := genGo(name, src)
+	if err != nil {
+		return nil, err
+	}
+
+	py, err := genPython(name, src)
+	if err != nil {
+		return nil, err
+	}
+
+	zodSchema, err := genZod(name, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var service string
+	parts := strings.SplitN(name, serviceSeparator, 2)
+	if len(parts) == 2 {
+		service = parts[0]
+	}
+
+	// Decode examples via JSON;  cue decodes empty lists as nil, which would
+	// otherwise change `[]` within examples to `null`.
+	examples := []map[string]interface{}{}
+	if ex := cueField(v, "examples"); ex.Value.Exists() && !ex.IsOptional {
+		byt, err := ex.Value.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding examples for %s: %w", name, err)
+		}
+		if err := json.Unmarshal(byt, &examples); err != nil {
+			return nil, fmt.Errorf("error decoding examples for %s: %w", name, err)
+		}
+	}
+
+	evt := &events.Event{
+		Name:        name,
+		Service:     service,
+		Description: cueString(v, "description"),
+		Schema:      schema,
+		TypeScript:  ts,
+		GoType:      goType,
+		Python:      py,
+		Zod:         zodSchema,
+		Cue:         src.String(),
+		Examples:    examples,
+		Version:     cueString(sf.Value, "v"),
+	}
+
+	if len(evt.Examples) == 0 && opts.GenerateExamples {
+		example, err := genExample(ctx, *evt)
+		if err != nil {
+			return nil, fmt.Errorf("error generating example for %s: %w", name, err)
+		}
+		evt.Examples = []map[string]interface{}{example}
+		evt.ExamplesGenerated = true
+	}
+
+	return evt, nil
+}
+
+// genExample generates an example event from the event's schema.  Examples
+// are seeded by the event's name and version, and are relative to a fixed
+// time, so that each event's example only changes when its schema changes.
+// Numbers are non-negative unless the schema requires otherwise, as most
+// numbers within events are IDs, counts or amounts.
+func genExample(ctx context.Context, evt events.Event) (map[string]interface{}, error) {
+	seed := fnv.New64a()
+	_, _ = seed.Write([]byte(evt.Name + "@" + evt.Version))
+
+	o := fakedata.DefaultOptions
+	o.Seed = int64(seed.Sum64())
+	o.Now = exampleTime
+	o.NonNegative = true
+	fake, err := fakedata.FakeEvent(ctx, evt, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the event to a map via JSON, as with captured examples.
+	byt, err := json.Marshal(fake)
+	if err != nil {
+		return nil, err
+	}
+	example := map[string]interface{}{}
+	if err := json.Unmarshal(byt, &example); err != nil {
+		return nil, err
+	}
+	return example, nil
+}
+
+func genTypescript(src source) (string, error) {
+	// Create a new struct which wraps the cue schema.
+	v, err := src.compile(typescriptEventName)
+	if err != nil {
+		return "", err
+	}
+	return typescript.MarshalCueValue(v)
+}
+
+// genGo generates Go types for the event, named after the event itself (eg.
+// "github/push" becomes GithubPush).
+func genGo(name string, src source) (string, error) {
+	v, err := src.compile(titleCaseName(name))
+	if err != nil {
+		return "", err
+	}
+	return golang.MarshalCueValue(v)
+}
+
+// genPython generates Python TypedDicts for the event, named after the event
+// itself.
+func genPython(name string, src source) (string, error) {
+	v, err := src.compile(titleCaseName(name))
+	if err != nil {
+		return "", err
+	}
+	return python.MarshalCueValue(v, python.Options{})
+}
+
+// genZod generates a zod schema for the event, named after the event itself.
+func genZod(name string, src source) (string, error) {
+	v, err := src.compile(titleCaseName(name))
+	if err != nil {
+		return "", err
+	}
+	return zod.MarshalCueValue(v)
+}
+
+func titleCaseName(name string) string {
+	name = nonAlphaRegexp.ReplaceAllString(name, " ")
+	name = strings.Title(name)
+	return strings.ReplaceAll(name, " ", "")
+}
+
+// formatNode formats a given cue node as well-defined cue config.
+func formatNode(input ast.Node) (string, error) {
+	out, err := format.Node(
+		input,
+		format.TabIndent(false),
+		format.UseSpaces(2),
+	)
+	return string(out), err
+}
@@ -0,0 +1,85 @@
+package parse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDefs(t *testing.T) {
+	evts, err := ParseDefs(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, evts)
+}
+
+func TestParseDir(t *testing.T) {
+	ctx := context.Background()
+
+	// Custom definitions require #Def.
+	_, err := ParseDir(ctx, "./testdata/custom", Options{})
+	require.Error(t, err)
+
+	evts, err := ParseDir(ctx, "./testdata/custom", Options{IncludeDef: true})
+	require.NoError(t, err)
+	require.Len(t, evts, 2)
+	require.Equal(t, "acme/user.created", evts[0].Name)
+	require.Equal(t, "acme", evts[0].Service)
+	require.Equal(t, "2022-01-01", evts[0].Version)
+	require.False(t, evts[0].Latest)
+	require.Equal(t, "2022-06-01", evts[1].Version)
+	require.True(t, evts[1].Latest)
+	require.Contains(t, evts[1].TypeScript, `"enterprise"`)
+	require.NotEmpty(t, evts[1].Schema)
+
+	// Definitions may extend #Def.
+	evts, err = ParseDir(ctx, "./testdata/extended", Options{IncludeDef: true})
+	require.NoError(t, err)
+	require.Len(t, evts, 1)
+	require.Equal(t, "billing/invoice.paid", evts[0].Name)
+	require.Equal(t, "Sent when an invoice is paid", evts[0].Description)
+}
+
+func TestParseGenerateExamples(t *testing.T) {
+	ctx := context.Background()
+
+	// Examples are only generated when requested.
+	evts, err := ParseDir(ctx, "./testdata/examples", Options{IncludeDef: true})
+	require.NoError(t, err)
+	require.Len(t, evts, 2)
+	require.Empty(t, evts[0].Examples)
+	require.False(t, evts[0].ExamplesGenerated)
+
+	evts, err = ParseDir(ctx, "./testdata/examples", Options{IncludeDef: true, GenerateExamples: true})
+	require.NoError(t, err)
+	require.Len(t, evts, 2)
+
+	placed := evts[0]
+	require.Equal(t, "shop/order.placed", placed.Name)
+	require.True(t, placed.ExamplesGenerated)
+	require.Len(t, placed.Examples, 1)
+	require.Contains(t, placed.Cue, "@fake(uuid)")
+
+	example := placed.Examples[0]
+	require.Equal(t, "shop/order.placed", example["name"])
+	require.Contains(t, example, "ts")
+	require.Contains(t, example, "user")
+	data := example["data"].(map[string]interface{})
+	require.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, data["id"])
+	require.Contains(t, []interface{}{"pending", "paid"}, data["status"])
+	require.Contains(t, []interface{}{"web", "store"}, data["channel"])
+	require.Contains(t, data, "notes")
+	require.Nil(t, data["notes"])
+
+	// Captured examples are left as-is.
+	shipped := evts[1]
+	require.False(t, shipped.ExamplesGenerated)
+	require.Equal(t, []map[string]interface{}{
+		{"name": "shop/order.shipped", "data": map[string]interface{}{"id": "1"}},
+	}, shipped.Examples)
+
+	// Generated examples are deterministic.
+	again, err := ParseDir(ctx, "./testdata/examples", Options{IncludeDef: true, GenerateExamples: true})
+	require.NoError(t, err)
+	require.Equal(t, evts[0].Examples, again[0].Examples)
+}
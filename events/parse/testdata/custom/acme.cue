@@ -0,0 +1,27 @@
+package eventdefintions
+
+user_created: #Def & {
+	description: "Sent when a user signs up"
+	schema: {
+		name: "acme/user.created"
+		data: {
+			id:    string
+			email: string
+			plan:  "free" | "pro"
+		}
+		v: "2022-01-01"
+	}
+}
+
+user_created_v2: #Def & {
+	description: "Sent when a user signs up"
+	schema: {
+		name: "acme/user.created"
+		data: {
+			id:    string
+			email: string
+			plan:  "free" | "pro" | "enterprise"
+		}
+		v: "2022-06-01"
+	}
+}
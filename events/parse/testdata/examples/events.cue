@@ -0,0 +1,29 @@
+package eventdefintions
+
+order_placed: #Def & {
+	description: "Sent when an order is placed"
+	schema: {
+		name: "shop/order.placed"
+		data: {
+			id:      string @fake(uuid)
+			total:   int & >=1 & <=1000
+			status:  "pending" | "paid"
+			channel: string @fake(enum=web|store)
+			notes:   _
+		}
+	}
+}
+
+order_shipped: #Def & {
+	description: "Sent when an order ships"
+	schema: {
+		name: "shop/order.shipped"
+		data: {
+			id: string
+		}
+	}
+	examples: [{
+		name: "shop/order.shipped"
+		data: id: "1"
+	}]
+}
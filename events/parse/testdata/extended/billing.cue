@@ -0,0 +1,13 @@
+package eventdefintions
+
+invoice_paid: #Def & {
+	owner:       "billing"
+	description: "Sent when an invoice is paid"
+	schema: {
+		name: "billing/invoice.paid"
+		data: {
+			invoice_id: string
+			amount:     int & >=0
+		}
+	}
+}
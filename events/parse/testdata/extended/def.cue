@@ -0,0 +1,6 @@
+package eventdefintions
+
+// Internal events must document the team that owns them.
+#Def: {
+	owner: string
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"path"
+)
+
+// Registry indexes a set of events by name, service and version.  The
+// embedded events are available via DefaultRegistry;  use NewRegistry to index
+// a custom set of event definitions.
+type Registry struct {
+	events []Event
+	// schemas caches the compiled schema of each event, by index.
+	schemas []*schema
+
+	// names lists each unique event name in the order they're defined.
+	names []string
+	// byName stores the index of each version of an event by name, ordered
+	// from oldest to newest.
+	byName map[string][]int
+	// byService stores the index of each event by service.
+	byService map[string][]int
+}
+
+// NewRegistry returns a registry indexing the given events.  Versions of each
+// event are sorted using GroupVersions, which marks the newest version of each
+// event as Latest.
+//
+// This returns an error if the same event name and version is defined more
+// than once.
+func NewRegistry(evts []Event) (*Registry, error) {
+	evts, err := GroupVersions(evts)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registry{
+		events:    evts,
+		schemas:   make([]*schema, len(evts)),
+		names:     []string{},
+		byName:    map[string][]int{},
+		byService: map[string][]int{},
+	}
+	for n, evt := range evts {
+		r.schemas[n] = &schema{}
+		if _, ok := r.byName[evt.Name]; !ok {
+			r.names = append(r.names, evt.Name)
+		}
+		r.byName[evt.Name] = append(r.byName[evt.Name], n)
+		r.byService[evt.Service] = append(r.byService[evt.Service], n)
+	}
+	return r, nil
+}
+
+// MustNewRegistry is like NewRegistry, but panics if the events can't be
+// indexed.
+func MustNewRegistry(evts []Event) *Registry {
+	r, err := NewRegistry(evts)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// All returns every event within the registry, with versions of each event
+// ordered from oldest to newest.
+func (r *Registry) All() []Event {
+	return r.events
+}
+
+// Get returns the latest version of the event with the given name.
+func (r *Registry) Get(name string) (Event, bool) {
+	n, ok := r.latest(name)
+	if !ok {
+		return Event{}, false
+	}
+	return r.events[n], true
+}
+
+// GetVersion returns the event with the given name and version.
+func (r *Registry) GetVersion(name, version string) (Event, bool) {
+	n, ok := r.find(name, version)
+	if !ok {
+		return Event{}, false
+	}
+	return r.events[n], true
+}
+
+// latest returns the index of the latest version of the event with the given
+// name.
+func (r *Registry) latest(name string) (int, bool) {
+	idx := r.byName[name]
+	if len(idx) == 0 {
+		return 0, false
+	}
+	return idx[len(idx)-1], true
+}
+
+// find returns the index of the event with the given name and version.
+func (r *Registry) find(name, version string) (int, bool) {
+	for _, n := range r.byName[name] {
+		if r.events[n].Version == version {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// Versions returns the version history of the event with the given name,
+// ordered from oldest to newest.
+func (r *Registry) Versions(name string) []Event {
+	return r.list(r.byName[name])
+}
+
+// ByService returns every event for the given service, eg. "github".
+func (r *Registry) ByService(service string) []Event {
+	return r.list(r.byService[service])
+}
+
+// Match returns every event whose name matches the given glob pattern, eg.
+// "github/*" or "stripe/charge.*".  Patterns use the syntax of path.Match;  as
+// with paths, "*" doesn't match the "/" separating the service from the event.
+//
+// If an event has many versions, only the latest version is included.
+func (r *Registry) Match(pattern string) ([]Event, error) {
+	result := []Event{}
+	for _, name := range r.names {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			evt, _ := r.Get(name)
+			result = append(result, evt)
+		}
+	}
+	return result, nil
+}
+
+func (r *Registry) list(idx []int) []Event {
+	result := make([]Event, len(idx))
+	for n, i := range idx {
+		result[n] = r.events[i]
+	}
+	return result
+}
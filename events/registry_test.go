@@ -0,0 +1,114 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	evt, ok := DefaultRegistry.Get("stripe/charge.failed")
+	require.True(t, ok)
+	require.Equal(t, "stripe", evt.Service)
+
+	_, ok = DefaultRegistry.Get("stripe/unknown")
+	require.False(t, ok)
+
+	for _, evt := range DefaultRegistry.ByService("github") {
+		require.Equal(t, "github", evt.Service)
+	}
+	require.Len(t, DefaultRegistry.ByService("stripe"), 3)
+	require.Len(t, DefaultRegistry.All(), len(Events))
+
+	for _, evt := range DefaultRegistry.All() {
+		versions := DefaultRegistry.Versions(evt.Name)
+		require.Equal(t, evt.Latest, versions[len(versions)-1].Version == evt.Version)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r, err := NewRegistry([]Event{
+		{Name: "acme/user.created", Service: "acme", Version: "2022-06-01"},
+		{Name: "acme/user.deleted", Service: "acme"},
+		{Name: "acme/user.created", Service: "acme", Version: "2022-01-01"},
+		{Name: "other/user.created", Service: "other"},
+	})
+	require.NoError(t, err)
+
+	evt, ok := r.Get("acme/user.created")
+	require.True(t, ok)
+	require.Equal(t, "2022-06-01", evt.Version)
+	require.True(t, evt.Latest)
+
+	evt, ok = r.GetVersion("acme/user.created", "2022-01-01")
+	require.True(t, ok)
+	require.Equal(t, "2022-01-01", evt.Version)
+	require.False(t, evt.Latest)
+
+	_, ok = r.GetVersion("acme/user.created", "2021-01-01")
+	require.False(t, ok)
+
+	versions := r.Versions("acme/user.created")
+	require.Len(t, versions, 2)
+	require.Equal(t, "2022-01-01", versions[0].Version)
+	require.Equal(t, "2022-06-01", versions[1].Version)
+
+	require.Len(t, r.ByService("acme"), 3)
+	require.Len(t, r.ByService("other"), 1)
+	require.Empty(t, r.ByService("github"))
+
+	matched, err := r.Match("acme/*")
+	require.NoError(t, err)
+	require.Len(t, matched, 2)
+	require.Equal(t, "acme/user.created", matched[0].Name)
+	require.Equal(t, "2022-06-01", matched[0].Version)
+	require.Equal(t, "acme/user.deleted", matched[1].Name)
+
+	matched, err = r.Match("*/user.created")
+	require.NoError(t, err)
+	require.Len(t, matched, 2)
+
+	matched, err = r.Match("*")
+	require.NoError(t, err)
+	require.Empty(t, matched)
+
+	_, err = r.Match("[")
+	require.Error(t, err)
+
+	duplicates := []Event{
+		{Name: "acme/user.created", Version: "1"},
+		{Name: "acme/user.created", Version: "1"},
+	}
+	_, err = NewRegistry(duplicates)
+	require.Error(t, err)
+	require.Panics(t, func() { MustNewRegistry(duplicates) })
+}
+
+func TestRegistryValidateVersion(t *testing.T) {
+	r, err := NewRegistry([]Event{
+		{Name: "acme/user.created", Version: "1", Cue: `{ name: string, data: { id: int }, v: "1" }`},
+		{Name: "acme/user.created", Version: "2", Cue: `{ name: string, data: { id: string }, v: "2" }`},
+	})
+	require.NoError(t, err)
+
+	v1 := map[string]interface{}{"name": "acme/user.created", "data": map[string]interface{}{"id": 1}, "v": "1"}
+	v2 := map[string]interface{}{"name": "acme/user.created", "data": map[string]interface{}{"id": "1"}, "v": "2"}
+	require.NoError(t, r.Validate("acme/user.created", v1))
+	require.NoError(t, r.Validate("acme/user.created", v2))
+
+	// The payload's version selects the schema.
+	v1["v"] = "2"
+	require.Error(t, r.Validate("acme/user.created", v1))
+
+	// Unknown versions of versioned events are rejected.
+	v1["v"] = "3"
+	require.True(t, errors.Is(r.Validate("acme/user.created", v1), ErrUnknownEvent))
+
+	// Payloads without a version use the latest version.
+	require.NoError(t, r.ValidateVersion("acme/user.created", "", map[string]interface{}{
+		"name": "acme/user.created",
+		"data": map[string]interface{}{"id": "1"},
+		"v":    "2",
+	}))
+}
@@ -0,0 +1,238 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/format"
+	cuejson "cuelang.org/go/encoding/json"
+)
+
+var (
+	// ErrUnknownEvent is returned when validating a payload for an event
+	// which isn't defined.
+	ErrUnknownEvent = errors.New("unknown event")
+
+	identifier = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+)
+
+// ValidationError describes a single field within a payload which doesn't
+// satisfy the event's schema.
+type ValidationError struct {
+	// Path is the JSON path to the field, eg. "$.data.amount".
+	Path string `json:"path"`
+	// Expected is the cue constraint that the field must satisfy, eg.
+	// "int & >=0".
+	Expected string `json:"expected"`
+	// Actual is the field's value within the payload, or nil if the field
+	// is missing.
+	Actual interface{} `json:"actual"`
+	// Message is cue's description of the error.
+	Message string `json:"message"`
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ValidationErrors lists every field within a payload which doesn't satisfy
+// the event's schema.
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for n, err := range v {
+		msgs[n] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate validates the payload against the schema for the event with the
+// given name within DefaultRegistry.  If the payload has a version within "v",
+// the schema for that version is used.
+//
+// This returns ErrUnknownEvent if the event isn't defined, or ValidationErrors
+// if the payload doesn't satisfy the schema.
+func Validate(name string, payload map[string]interface{}) error {
+	return DefaultRegistry.Validate(name, payload)
+}
+
+// ValidateVersion validates the payload against the schema for the given
+// event name and version within DefaultRegistry.
+func ValidateVersion(name, version string, payload map[string]interface{}) error {
+	return DefaultRegistry.ValidateVersion(name, version, payload)
+}
+
+// Validate validates the payload against the schema for the event with the
+// given name.  If the payload has a version within "v", the schema for that
+// version is used.
+func (r *Registry) Validate(name string, payload map[string]interface{}) error {
+	version, _ := payload["v"].(string)
+	return r.ValidateVersion(name, version, payload)
+}
+
+// ValidateVersion validates the payload against the schema for the given
+// event name and version.  An empty version uses the latest version of the
+// event, and events without a version accept payloads for any version.
+//
+// Each event's schema is compiled once, when the event is first validated.
+func (r *Registry) ValidateVersion(name, version string, payload map[string]interface{}) error {
+	n, ok := r.find(name, version)
+	if !ok && version == "" {
+		// Payloads without a version use the latest version's schema.
+		n, ok = r.latest(name)
+	}
+	if !ok {
+		n, ok = r.find(name, "")
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownEvent, name)
+	}
+	return r.schemas[n].validate(r.events[n], payload)
+}
+
+// Validate validates the payload against the event's schema, returning
+// ValidationErrors if the payload doesn't satisfy the schema.  This compiles
+// the schema on every call;  use a Registry to validate many payloads.
+func (e Event) Validate(payload map[string]interface{}) error {
+	return (&schema{}).validate(e, payload)
+}
+
+// schema caches an event's compiled schema.  Payloads are built within the
+// schema's runtime so that they can be unified with the schema.  Cue values
+// aren't safe for concurrent use, so validation is serialized.
+type schema struct {
+	once sync.Once
+	lock sync.Mutex
+	val  cue.Value
+	err  error
+}
+
+func (s *schema) validate(e Event, payload map[string]interface{}) error {
+	s.once.Do(func() {
+		r := &cue.Runtime{}
+		inst, err := r.Compile("schema.cue", e.Cue)
+		if err != nil {
+			s.err = fmt.Errorf("error compiling schema for %s: %w", e.Name, err)
+			return
+		}
+		s.val = inst.Value()
+	})
+	if s.err != nil {
+		return s.err
+	}
+
+	byt, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding payload: %w", err)
+	}
+	// Extracting the payload from JSON ensures that integers remain
+	// integers.
+	expr, err := cuejson.Extract("payload.json", byt)
+	if err != nil {
+		return fmt.Errorf("error compiling payload: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data := s.val.Context().BuildExpr(expr)
+	if err := data.Err(); err != nil {
+		return fmt.Errorf("error compiling payload: %w", err)
+	}
+
+	err = s.val.Unify(data).Validate(cue.Concrete(true))
+	if err == nil {
+		return nil
+	}
+
+	result := ValidationErrors{}
+	for _, cerr := range cueerrors.Errors(cueerrors.Sanitize(cueerrors.Promote(err, ""))) {
+		path := cerr.Path()
+		format, args := cerr.Msg()
+		result = append(result, ValidationError{
+			Path:     jsonPath(payload, path),
+			Expected: expected(s.val, path),
+			Actual:   actual(payload, path),
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	return result
+}
+
+// jsonPath returns the JSON path for the given cue path, using the payload to
+// determine which selectors are list indexes.
+func jsonPath(payload interface{}, path []string) string {
+	str := strings.Builder{}
+	_, _ = str.WriteString("$")
+	value := payload
+	for _, sel := range path {
+		_, isList := value.([]interface{})
+		switch {
+		case isList:
+			_, _ = str.WriteString(fmt.Sprintf("[%s]", sel))
+		case identifier.MatchString(sel):
+			_, _ = str.WriteString("." + sel)
+		default:
+			_, _ = str.WriteString(fmt.Sprintf("[%s]", strconv.Quote(sel)))
+		}
+		value = lookup(value, sel)
+	}
+	return str.String()
+}
+
+// actual returns the value within the payload at the given cue path, or nil
+// if the path doesn't exist.
+func actual(payload interface{}, path []string) interface{} {
+	value := payload
+	for _, sel := range path {
+		value = lookup(value, sel)
+	}
+	return value
+}
+
+func lookup(value interface{}, sel string) interface{} {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		return t[sel]
+	case []interface{}:
+		i, err := strconv.Atoi(sel)
+		if err != nil || i < 0 || i >= len(t) {
+			return nil
+		}
+		return t[i]
+	}
+	return nil
+}
+
+// expected returns the formatted constraint within the schema at the given
+// cue path.
+func expected(schema cue.Value, path []string) string {
+	value := schema
+	for _, sel := range path {
+		next := value.LookupPath(cue.MakePath(cue.Str(sel)))
+		if i, err := strconv.Atoi(sel); err == nil && value.IncompleteKind() == cue.ListKind {
+			next = value.LookupPath(cue.MakePath(cue.Index(i)))
+			if !next.Exists() {
+				next = value.LookupPath(cue.MakePath(cue.AnyIndex))
+			}
+		}
+		if !next.Exists() {
+			return ""
+		}
+		value = next
+	}
+
+	out, err := format.Node(value.Syntax(cue.Optional(true)))
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
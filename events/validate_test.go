@@ -0,0 +1,82 @@
+package events
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExamples(t *testing.T) {
+	for _, evt := range Events {
+		for _, example := range evt.Examples {
+			require.NoError(t, Validate(evt.Name, example), evt.Name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := Validate("unknown/event", map[string]interface{}{})
+	require.True(t, errors.Is(err, ErrUnknownEvent))
+
+	payload := map[string]interface{}{
+		"name": "stripe/charge.failed",
+		"data": map[string]interface{}{
+			"id":     "evt_123",
+			"object": 1,
+		},
+	}
+	err = Validate("stripe/charge.failed", payload)
+	require.Error(t, err)
+
+	verrs := ValidationErrors{}
+	require.True(t, errors.As(err, &verrs))
+	require.NotEmpty(t, verrs)
+
+	var found bool
+	for _, v := range verrs {
+		if v.Path != "$.data.object" {
+			continue
+		}
+		found = true
+		require.Equal(t, "string", v.Expected)
+		require.EqualValues(t, 1, v.Actual)
+	}
+	require.True(t, found, "expected an error for $.data.object: %s", err)
+}
+
+func TestRegistryValidateConcurrently(t *testing.T) {
+	r, err := NewRegistry([]Event{
+		{Name: "acme/signup", Cue: `{ name: "acme/signup", data: { count: int } }`},
+	})
+	require.NoError(t, err)
+
+	// require can't be called outside of the test's goroutine, so errors are
+	// collected and checked once every goroutine is done.
+	const n = 20
+	validErrs := make([]error, n)
+	invalidErrs := make([]error, n)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			valid := map[string]interface{}{"name": "acme/signup", "data": map[string]interface{}{"count": i}}
+			validErrs[i] = r.Validate("acme/signup", valid)
+
+			invalid := map[string]interface{}{"name": "acme/signup", "data": map[string]interface{}{"count": 1.5}}
+			invalidErrs[i] = r.Validate("acme/signup", invalid)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, validErrs[i])
+		require.Error(t, invalidErrs[i])
+	}
+
+	// The schema is compiled once and reused.
+	require.True(t, r.schemas[0].val.Exists())
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GroupVersions groups events by name, sorting each event's versions from
+// oldest to newest and marking the newest version as Latest.  Groups are
+// returned in the order each name is first defined.
+//
+// Events without a version sort before any versioned event.  This returns an
+// error if the same name and version is defined more than once.
+func GroupVersions(evts []Event) ([]Event, error) {
+	names := []string{}
+	groups := map[string][]Event{}
+	for _, evt := range evts {
+		if _, ok := groups[evt.Name]; !ok {
+			names = append(names, evt.Name)
+		}
+		for _, existing := range groups[evt.Name] {
+			if existing.Version == evt.Version {
+				return nil, fmt.Errorf("duplicate event definition: %s (version %q)", evt.Name, evt.Version)
+			}
+		}
+		groups[evt.Name] = append(groups[evt.Name], evt)
+	}
+
+	result := make([]Event, 0, len(evts))
+	for _, name := range names {
+		group := groups[name]
+		sort.SliceStable(group, func(i, j int) bool {
+			return CompareVersions(group[i].Version, group[j].Version) < 0
+		})
+		for n := range group {
+			group[n].Latest = n == len(group)-1
+		}
+		result = append(result, group...)
+	}
+	return result, nil
+}
+
+// CompareVersions compares two event versions, returning -1 if a is older than
+// b, 0 if they're equal and 1 if a is newer than b.
+//
+// Versions are compared in runs of digits and non-digits, so that numeric
+// parts compare as numbers:  "2" is older than "10", "1.2.0" is older than
+// "1.10.0", and dates such as "2022-01-31" compare chronologically.  An empty
+// version is older than any other version.
+func CompareVersions(a, b string) int {
+	as, bs := versionParts(a), versionParts(b)
+	for n := 0; n < len(as) && n < len(bs); n++ {
+		if c := comparePart(as[n], bs[n]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+func comparePart(a, b string) int {
+	ai, aerr := strconv.ParseUint(a, 10, 64)
+	bi, berr := strconv.ParseUint(b, 10, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// versionParts splits a version into runs of digits and non-digits, eg.
+// "v1.10" becomes ["v", "1", ".", "10"].
+func versionParts(v string) []string {
+	parts := []string{}
+	for len(v) > 0 {
+		digit := unicode.IsDigit(rune(v[0]))
+		end := strings.IndexFunc(v, func(r rune) bool {
+			return unicode.IsDigit(r) != digit
+		})
+		if end < 0 {
+			end = len(v)
+		}
+		parts = append(parts, v[:end])
+		v = v[end:]
+	}
+	return parts
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "1", -1},
+		{"1", "", 1},
+		{"1", "1", 0},
+		{"1", "2", -1},
+		{"2", "10", -1},
+		{"v2", "v10", -1},
+		{"1.2.0", "1.10.0", -1},
+		{"1.2", "1.2.1", -1},
+		{"2022-01-31", "2022-02-01", -1},
+		{"2022-12-01", "2022-02-01", 1},
+		{"beta", "alpha", 1},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.expected, CompareVersions(test.a, test.b), "%q vs %q", test.a, test.b)
+	}
+}
+
+func TestGroupVersions(t *testing.T) {
+	evts, err := GroupVersions([]Event{
+		{Name: "b", Version: "10"},
+		{Name: "a"},
+		{Name: "b", Version: "2"},
+		{Name: "b"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Event{
+		{Name: "b", Version: ""},
+		{Name: "b", Version: "2"},
+		{Name: "b", Version: "10", Latest: true},
+		{Name: "a", Latest: true},
+	}, evts)
+
+	_, err = GroupVersions([]Event{{Name: "a"}, {Name: "a"}})
+	require.Error(t, err)
+}
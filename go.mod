@@ -6,12 +6,12 @@ replace cuelang.org/go => github.com/inngest/cue v0.4.6
 
 require (
 	cuelang.org/go v0.4.5
-	github.com/bxcodec/faker/v3 v3.8.0
 	github.com/google/uuid v1.2.0
 	github.com/inngest/cuetypescript v0.0.0-20220302153725-a00e933fdf87
 	github.com/inngest/golorem v0.0.0-20220323014825-15bf7a53bf79
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/tools v0.6.0
+	gopkg.in/yaml.v3 v3.0.0
 )
 
 require (
@@ -26,5 +26,4 @@ require (
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/text v0.13.0 // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
-	gopkg.in/yaml.v3 v3.0.0 // indirect
 )
@@ -0,0 +1,355 @@
+// Package compat detects breaking changes between two versions of an event
+// schema.
+//
+// Whether a change is breaking depends on the viewpoint:
+//
+//   - A change breaks producers if a payload which satisfied the old schema
+//     no longer satisfies the new schema, eg. making an optional field
+//     required, narrowing a type or removing an enum member.
+//   - A change breaks consumers if a payload which satisfies the new schema
+//     may not satisfy the old schema that consumers were written against, eg.
+//     removing a field, making a required field optional, widening a type or
+//     adding an enum member.
+//
+// By default, changes which break either consumers or producers are breaking.
+// Removing a field breaks both, as definitions may be closed;  adding an
+// optional field breaks neither.
+package compat
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/events"
+	"github.com/inngest/event-schemas/pkg/cueutil"
+	"github.com/inngest/event-schemas/pkg/merge"
+)
+
+// ChangeKind describes a single difference between two schemas.
+type ChangeKind string
+
+const (
+	FieldAdded          ChangeKind = "field added"
+	FieldRemoved        ChangeKind = "field removed"
+	FieldRequired       ChangeKind = "field required"
+	FieldOptional       ChangeKind = "field optional"
+	TypeNarrowed        ChangeKind = "type narrowed"
+	TypeWidened         ChangeKind = "type widened"
+	TypeChanged         ChangeKind = "type changed"
+	EnumMemberAdded     ChangeKind = "enum member added"
+	EnumMemberRemoved   ChangeKind = "enum member removed"
+	ConstraintTightened ChangeKind = "constraint tightened"
+	ConstraintLoosened  ChangeKind = "constraint loosened"
+	ConstraintChanged   ChangeKind = "constraint changed"
+)
+
+// breaks lists whether each kind of change breaks consumers and producers.
+var breaks = map[ChangeKind]struct{ consumers, producers bool }{
+	FieldRemoved:        {true, true},
+	FieldRequired:       {false, true},
+	FieldOptional:       {true, false},
+	TypeNarrowed:        {false, true},
+	TypeWidened:         {true, false},
+	TypeChanged:         {true, true},
+	EnumMemberAdded:     {true, false},
+	EnumMemberRemoved:   {false, true},
+	ConstraintTightened: {false, true},
+	ConstraintLoosened:  {true, false},
+	ConstraintChanged:   {true, true},
+}
+
+// Viewpoint determines which changes are breaking.
+type Viewpoint int
+
+const (
+	// ConsumersAndProducers treats changes which break either consumers or
+	// producers as breaking.  This is the default.
+	ConsumersAndProducers Viewpoint = iota
+	// Consumers treats changes which break consumers of the event, which
+	// were written against the old schema, as breaking.
+	Consumers
+	// Producers treats changes which break payloads sent using the old
+	// schema as breaking.
+	Producers
+)
+
+// Options configures comparisons.
+type Options struct {
+	// Viewpoint determines which changes are breaking.
+	Viewpoint Viewpoint
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+// Change is a single difference between two schemas.
+type Change struct {
+	// Path is the JSON path to the changed field, eg. "$.data.user.id".  List
+	// elements are represented using "[*]".
+	Path string `json:"path"`
+	// Kind describes the change.
+	Kind ChangeKind `json:"kind"`
+	// Breaking is true if the change is breaking from the comparison's
+	// viewpoint.
+	Breaking bool `json:"breaking"`
+	// BreaksConsumers is true if payloads satisfying the new schema may not
+	// satisfy the old schema.
+	BreaksConsumers bool `json:"breaksConsumers"`
+	// BreaksProducers is true if payloads satisfying the old schema may not
+	// satisfy the new schema.
+	BreaksProducers bool `json:"breaksProducers"`
+	// Old is the formatted cue value within the old schema, if any.
+	Old string `json:"old,omitempty"`
+	// New is the formatted cue value within the new schema, if any.
+	New string `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	severity := "non-breaking"
+	if c.Breaking {
+		severity = "breaking"
+	}
+	return fmt.Sprintf("%s: %s: %s", severity, c.Path, c.Kind)
+}
+
+// Report lists every difference between two schemas, in the order that the
+// fields are defined.  Changes are breaking according to the viewpoint given
+// in Options, which defaults to changes which break either consumers or
+// producers.
+type Report struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns true if any change within the report is breaking.
+func (r Report) Breaking() bool {
+	return len(r.BreakingChanges()) > 0
+}
+
+// BreakingChanges returns each breaking change within the report.
+func (r Report) BreakingChanges() []Change {
+	result := []Change{}
+	for _, c := range r.Changes {
+		if c.Breaking {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (r Report) String() string {
+	lines := make([]string, len(r.Changes))
+	for n, c := range r.Changes {
+		lines[n] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareEvents compares the cue definitions of two events, eg. two versions
+// of the same event.
+func CompareEvents(ctx context.Context, old, new events.Event, o Options) (Report, error) {
+	return CompareString(ctx, old.Cue, new.Cue, o)
+}
+
+// CompareString compiles and compares two cue schemas.
+func CompareString(ctx context.Context, old, new string, o Options) (Report, error) {
+	r := &cue.Runtime{}
+	a, err := r.Compile("old.cue", old)
+	if err != nil {
+		return Report{}, fmt.Errorf("error compiling old schema: %w", err)
+	}
+	b, err := r.Compile("new.cue", new)
+	if err != nil {
+		return Report{}, fmt.Errorf("error compiling new schema: %w", err)
+	}
+	return Compare(ctx, a.Value(), b.Value(), o)
+}
+
+// Compare compares two cue values, returning a report of every difference
+// between the old and new value.
+func Compare(ctx context.Context, old, new cue.Value, o Options) (Report, error) {
+	c := &comparer{r: &cue.Runtime{}, o: o}
+	if err := c.compare("$", old, new); err != nil {
+		return Report{}, err
+	}
+	return Report{Changes: c.changes}, nil
+}
+
+type comparer struct {
+	r       *cue.Runtime
+	o       Options
+	changes []Change
+}
+
+// add adds a change, which breaks consumers and producers as listed in
+// breaks unless otherwise given.
+func (c *comparer) add(path string, kind ChangeKind, old, new cue.Value) {
+	b := breaks[kind]
+	c.addBreaking(path, kind, b.consumers, b.producers, old, new)
+}
+
+func (c *comparer) addBreaking(path string, kind ChangeKind, consumers, producers bool, old, new cue.Value) {
+	breaking := consumers || producers
+	switch c.o.Viewpoint {
+	case Consumers:
+		breaking = consumers
+	case Producers:
+		breaking = producers
+	}
+	c.changes = append(c.changes, Change{
+		Path:            path,
+		Kind:            kind,
+		Breaking:        breaking,
+		BreaksConsumers: consumers,
+		BreaksProducers: producers,
+		Old:             format(old),
+		New:             format(new),
+	})
+}
+
+func (c *comparer) compare(path string, old, new cue.Value) error {
+	oldKind, newKind := old.IncompleteKind(), new.IncompleteKind()
+
+	// Compare the elements of two lists.  This happens before checking
+	// whether the values are equivalent, as a list of one type subsumes a list
+	// of another.
+	//
+	// See https://github.com/cue-lang/cue/issues/1654 for more info.
+	if oldKind == cue.ListKind && newKind == cue.ListKind {
+		oldElem := old.LookupPath(cue.MakePath(cue.AnyIndex))
+		newElem := new.LookupPath(cue.MakePath(cue.AnyIndex))
+		if oldElem.Exists() && newElem.Exists() {
+			return c.compare(path+"[*]", oldElem, newElem)
+		}
+	}
+
+	if equivalent(old, new) {
+		return nil
+	}
+
+	// Compare each field within two structs.
+	if oldKind == cue.StructKind && newKind == cue.StructKind {
+		return merge.WalkFields(old, new, func(label string, a, b cue.FieldInfo) error {
+			if a.IsDefinition || b.IsDefinition {
+				return nil
+			}
+			return c.compareField(fieldPath(path, label), a, b)
+		})
+	}
+
+	// Compare the members of two enums.
+	oldMembers, err := c.enum(old)
+	if err != nil {
+		return err
+	}
+	newMembers, err := c.enum(new)
+	if err != nil {
+		return err
+	}
+	if oldMembers != nil && newMembers != nil {
+		for _, m := range oldMembers {
+			if !contains(newMembers, m) {
+				c.add(path, EnumMemberRemoved, m, cue.Value{})
+			}
+		}
+		for _, m := range newMembers {
+			if !contains(oldMembers, m) {
+				c.add(path, EnumMemberAdded, cue.Value{}, m)
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case oldKind != newKind && oldKind&newKind == oldKind:
+		c.add(path, TypeWidened, old, new)
+	case oldKind != newKind && oldKind&newKind == newKind:
+		c.add(path, TypeNarrowed, old, new)
+	case oldKind != newKind:
+		c.add(path, TypeChanged, old, new)
+	case new.Subsume(old, cue.Schema()) == nil:
+		c.add(path, ConstraintLoosened, old, new)
+	case old.Subsume(new, cue.Schema()) == nil:
+		c.add(path, ConstraintTightened, old, new)
+	default:
+		c.add(path, ConstraintChanged, old, new)
+	}
+	return nil
+}
+
+func (c *comparer) compareField(path string, old, new cue.FieldInfo) error {
+	switch {
+	case new.Value.IncompleteKind() == cue.BottomKind:
+		c.add(path, FieldRemoved, old.Value, cue.Value{})
+		return nil
+	case old.Value.IncompleteKind() == cue.BottomKind:
+		// Adding a required field breaks payloads which don't contain the
+		// field.
+		c.addBreaking(path, FieldAdded, false, !new.IsOptional, cue.Value{}, new.Value)
+		return nil
+	case old.IsOptional && !new.IsOptional:
+		c.add(path, FieldRequired, old.Value, new.Value)
+	case !old.IsOptional && new.IsOptional:
+		c.add(path, FieldOptional, old.Value, new.Value)
+	}
+	return c.compare(path, old.Value, new.Value)
+}
+
+// enum returns the members of the value if the value is a union of concrete
+// values, eg. `"open" | "closed"`, or nil otherwise.
+func (c *comparer) enum(v cue.Value) ([]cue.Value, error) {
+	members, err := merge.ExpandValues(c.r, v)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) < 2 && !v.IsConcrete() {
+		return nil, nil
+	}
+	for _, m := range members {
+		if !m.IsConcrete() || m.IncompleteKind() == cue.StructKind || m.IncompleteKind() == cue.ListKind {
+			return nil, nil
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return format(members[i]) < format(members[j])
+	})
+	return members, nil
+}
+
+func contains(values []cue.Value, v cue.Value) bool {
+	for _, item := range values {
+		if equivalent(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equivalent returns true if each value subsumes the other.
+func equivalent(a, b cue.Value) bool {
+	return a.Subsume(b, cue.Schema()) == nil && b.Subsume(a, cue.Schema()) == nil
+}
+
+// fieldPath appends the label to the given JSON path.
+func fieldPath(path, label string) string {
+	if identifier.MatchString(label) {
+		return path + "." + label
+	}
+	return fmt.Sprintf("%s[%s]", path, strconv.Quote(label))
+}
+
+// format returns the value as formatted cue, or an empty string if the value
+// doesn't exist.
+func format(v cue.Value) string {
+	if !v.Exists() {
+		return ""
+	}
+	str, err := cueutil.ASTToSyntax(v.Syntax())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(str)
+}
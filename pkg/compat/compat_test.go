@@ -0,0 +1,97 @@
+package compat
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/inngest/event-schemas/events"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/txtar"
+)
+
+func TestCompare(t *testing.T) {
+	entries, err := os.ReadDir("./testdata")
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".txtar") {
+			continue
+		}
+
+		t.Run(e.Name(), func(t *testing.T) {
+			archive, err := txtar.ParseFile(path.Join("./testdata", e.Name()))
+			require.NoError(t, err)
+
+			files := map[string]string{}
+			for _, f := range archive.Files {
+				files[f.Name] = string(f.Data)
+			}
+
+			// expected uses the default viewpoint;  consumers and producers
+			// use their respective viewpoints.
+			viewpoints := map[string]Viewpoint{
+				"expected":  ConsumersAndProducers,
+				"consumers": Consumers,
+				"producers": Producers,
+			}
+			for section, viewpoint := range viewpoints {
+				expected, ok := files[section]
+				require.True(t, ok, "missing section %s", section)
+
+				report, err := CompareString(context.Background(), files["old"], files["new"], Options{Viewpoint: viewpoint})
+				require.NoError(t, err)
+				require.Equal(t, strings.TrimSpace(expected), report.String(), section)
+			}
+		})
+	}
+}
+
+func TestCompareEvents(t *testing.T) {
+	ctx := context.Background()
+
+	// Every event is compatible with itself.
+	for _, evt := range events.Events {
+		report, err := CompareEvents(ctx, evt, evt, Options{})
+		require.NoError(t, err)
+		require.Empty(t, report.Changes, evt.Name)
+	}
+
+	// Making a field optional breaks consumers, but not producers.
+	optional := []events.Event{
+		{Cue: `{ name: string, data: { id: string, amount: int } }`},
+		{Cue: `{ name: string, data: { id: string, amount?: int, currency?: string } }`},
+	}
+	report, err := CompareEvents(ctx, optional[0], optional[1], Options{})
+	require.NoError(t, err)
+	require.True(t, report.Breaking())
+	require.Len(t, report.Changes, 2)
+
+	report, err = CompareEvents(ctx, optional[0], optional[1], Options{Viewpoint: Producers})
+	require.NoError(t, err)
+	require.False(t, report.Breaking())
+	require.Len(t, report.Changes, 2)
+
+	report, err = CompareEvents(ctx, optional[0], optional[1], Options{Viewpoint: Consumers})
+	require.NoError(t, err)
+	require.True(t, report.Breaking())
+	require.Len(t, report.BreakingChanges(), 1)
+
+	report, err = CompareEvents(ctx,
+		events.Event{Cue: `{ name: string, data: { id: string, amount: int } }`},
+		events.Event{Cue: `{ name: string, data: { id: string } }`},
+		Options{},
+	)
+	require.NoError(t, err)
+	require.True(t, report.Breaking())
+	require.Equal(t, []Change{{
+		Path:            "$.data.amount",
+		Kind:            FieldRemoved,
+		Breaking:        true,
+		BreaksConsumers: true,
+		BreaksProducers: true,
+		Old:             "int",
+	}}, report.BreakingChanges())
+}
@@ -0,0 +1,224 @@
+package fakedata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+)
+
+// attrName is the name of the attribute which declares how to generate a
+// field's data, eg:
+//
+//	email:  string @fake(email)
+//	id:     string @fake(stripe_id)
+//	status: string @fake(enum=active|inactive)
+//	count:  int @fake(range="1..100")
+//	sha:    string @fake(regex="^[0-9a-f]{40}$")
+//
+// Ranges and regular expressions are quoted, as cue doesn't allow ".." or
+// other special characters within unquoted attribute values.
+const attrName = "fake"
+
+// GeneratorFunc generates fake data for a field, using the given random source
+// so that data is reproducible.  The returned value must marshal to JSON.
+type GeneratorFunc func(ctx context.Context, r *rand.Rand) (interface{}, error)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]GeneratorFunc{}
+
+	// attrFormats are the built-in generators available via attributes.
+	attrFormats = map[string]Format{
+		"email": FormatEmail,
+		"url":   FormatURL,
+		"phone": FormatPhone,
+		"name":  FormatName,
+		"ipv6":  FormatIPv6,
+		"ipv4":  FormatIPv4,
+		"date":  FormatDate,
+		"time":  FormatTime,
+		"title": FormatTitle,
+		"uuid":  FormatUUID,
+	}
+)
+
+// Register registers a named generator, which fields use via attributes such
+// as `@fake(stripe_id)`.  Registered generators take precedence over built-in
+// generators with the same name, and registering a name again replaces the
+// previous generator.
+func Register(name string, fn GeneratorFunc) {
+	if fn == nil {
+		panic("fakedata: Register generator is nil")
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[name] = fn
+}
+
+func registered(name string) (GeneratorFunc, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	fn, ok := registry[name]
+	return fn, ok
+}
+
+// fakeAttr returns cue AST representing fake data for a value with a @fake
+// attribute.  Attributes take precedence over the formats predicted from the
+// field's name, though the value must still satisfy the field's definition.
+func fakeAttr(ctx context.Context, val cue.Value, attr cue.Attribute, o Options) (ast.Expr, bool, error) {
+	// Nullable fields, eg. `string | null @fake(email)`, are null half of the
+	// time, and otherwise use the attribute to generate the non-null value.
+	if k := val.IncompleteKind(); k&cue.NullKind != 0 && k != cue.NullKind {
+		if o.rand.Intn(2) == 0 {
+			return ast.NewNull(), true, nil
+		}
+		val = withoutNull(val, o)
+	}
+
+	kind, ok := attrKinds[val.IncompleteKind()]
+
+	c := []Constraint{}
+	for i := 0; i < attr.NumArgs(); i++ {
+		key, value := attr.Arg(i)
+
+		if fn, found := registered(key); found && value == "" {
+			return genRegistered(ctx, fn, o)
+		}
+		if !ok {
+			return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("@%s(%s) is unsupported for %s", attrName, attr.Contents(), val.IncompleteKind())}
+		}
+
+		switch key {
+		case "enum":
+			choices := []interface{}{}
+			for _, item := range strings.Split(value, "|") {
+				choice, err := parseAttrValue(kind, item)
+				if err != nil {
+					return nil, false, &FieldError{Path: path(ctx), Err: err}
+				}
+				choices = append(choices, choice)
+			}
+			c = append(c, Constraint{Rule: RuleOneOf, Value: choices})
+		case "range":
+			bounds := strings.SplitN(value, "..", 2)
+			if len(bounds) != 2 || kind == KindString {
+				return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("invalid range: %q", value)}
+			}
+			min, err := parseAttrValue(kind, bounds[0])
+			if err != nil {
+				return nil, false, &FieldError{Path: path(ctx), Err: err}
+			}
+			max, err := parseAttrValue(kind, bounds[1])
+			if err != nil {
+				return nil, false, &FieldError{Path: path(ctx), Err: err}
+			}
+			c = append(c, Constraint{Rule: RuleGTE, Value: min}, Constraint{Rule: RuleLTE, Value: max})
+		case "regex":
+			if kind != KindString {
+				return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("@%s(%s) is unsupported for %s", attrName, attr.Contents(), val.IncompleteKind())}
+			}
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("invalid regex: %q", value)}
+			}
+			c = append(c, Constraint{Rule: RuleRegex, Value: value})
+		default:
+			f, found := attrFormats[key]
+			if !found || value != "" || kind != KindString {
+				return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("unknown generator: @%s(%s)", attrName, attr.Contents())}
+			}
+			c = append(c, Constraint{Rule: RuleFormat, Value: f})
+		}
+	}
+
+	// Combine the attribute's constraints with the definition's constraints,
+	// without predicting formats from the field's name.
+	defined, err := constraints(context.WithValue(ctx, ctxPath, ""), kind, o, val)
+	if err != nil {
+		return nil, false, &FieldError{Path: path(ctx), Err: err}
+	}
+	if kind == KindString {
+		// Strings use the first constraint which generates a value.
+		c = append(c, defined...)
+	} else {
+		// Numbers apply each constraint in turn, so the attribute's bounds
+		// override the definition's bounds.
+		c = append(defined, c...)
+	}
+
+	f := generatorFunc(ctx, kind, o, c...)
+	switch kind {
+	case KindString:
+		return ast.NewLit(token.STRING, strconv.Quote(f.(string))), true, nil
+	case KindInt:
+		return ast.NewLit(token.INT, strconv.Itoa(f.(int))), true, nil
+	}
+	byt, _ := json.Marshal(f)
+	return ast.NewLit(token.FLOAT, string(byt)), true, nil
+}
+
+// attrKinds are the kinds which support built-in generators, enums and ranges.
+var attrKinds = map[cue.Kind]Kind{
+	cue.StringKind: KindString,
+	cue.IntKind:    KindInt,
+	cue.FloatKind:  KindFloat,
+	cue.NumberKind: KindFloat,
+}
+
+// withoutNull returns one of the non-null elements of a nullable disjunction,
+// eg. `string` for `string | null`.
+func withoutNull(val cue.Value, o Options) cue.Value {
+	op, exprVals := val.Expr()
+	if op != cue.OrOp {
+		return val
+	}
+	vals := []cue.Value{}
+	for _, v := range exprVals {
+		if v.IncompleteKind() != cue.NullKind {
+			vals = append(vals, v)
+		}
+	}
+	if len(vals) == 0 {
+		return val
+	}
+	return vals[o.rand.Intn(len(vals))]
+}
+
+// genRegistered returns cue AST representing the data generated by a
+// registered generator.
+func genRegistered(ctx context.Context, fn GeneratorFunc, o Options) (ast.Expr, bool, error) {
+	v, err := fn(ctx, o.rand)
+	if err != nil {
+		return nil, false, &FieldError{Path: path(ctx), Err: err}
+	}
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("error encoding generated value: %w", err)}
+	}
+	expr, err := parser.ParseExpr("fake", byt)
+	if err != nil {
+		return nil, false, &FieldError{Path: path(ctx), Err: fmt.Errorf("error parsing generated value: %w", err)}
+	}
+	return expr, true, nil
+}
+
+// parseAttrValue parses a value within an attribute as the given kind.
+func parseAttrValue(kind Kind, s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	switch kind {
+	case KindInt:
+		return strconv.Atoi(s)
+	case KindFloat:
+		return strconv.ParseFloat(s, 64)
+	}
+	return s, nil
+}
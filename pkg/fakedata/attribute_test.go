@@ -0,0 +1,120 @@
+package fakedata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeAttributes(t *testing.T) {
+	Register("test_stripe_id", func(ctx context.Context, r *rand.Rand) (interface{}, error) {
+		return fmt.Sprintf("ch_%08d", r.Intn(1e8)), nil
+	})
+	Register("test_address", func(ctx context.Context, r *rand.Rand) (interface{}, error) {
+		return map[string]interface{}{"city": "Paris", "lines": []string{}}, nil
+	})
+
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		contact:  string @fake(email)
+		format:   string @fake(uuid)
+		id:       string @fake(test_stripe_id)
+		address:  { city: string, lines: [...string] } @fake(test_address)
+		status:   string @fake(enum=active|inactive)
+		priority: int @fake(enum=1|2|3)
+		count:    int & >= 0 @fake(range="5..10")
+		ratio:    float @fake(range="0.5..0.75")
+		sha:      string @fake(regex="^[0-9a-f]{40}$")
+		backup:   string | null @fake(email)
+		limit:    *null | int @fake(range="1..3")
+	}`)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+
+		mapped := map[string]interface{}{}
+		require.NoError(t, output.Decode(&mapped))
+
+		require.Regexp(t, `^[^@]+@[^@]+$`, mapped["contact"])
+		_, err = uuid.Parse(mapped["format"].(string))
+		require.NoError(t, err, "format is a uuid, not a time")
+		require.Regexp(t, `^ch_\d{8}$`, mapped["id"])
+		require.Equal(t, "Paris", mapped["address"].(map[string]interface{})["city"])
+		require.Contains(t, []interface{}{"active", "inactive"}, mapped["status"])
+		require.Contains(t, []interface{}{1, 2, 3}, mapped["priority"])
+		require.GreaterOrEqual(t, mapped["count"], 5)
+		require.LessOrEqual(t, mapped["count"], 10)
+		require.GreaterOrEqual(t, mapped["ratio"], 0.5)
+		require.LessOrEqual(t, mapped["ratio"], 0.75)
+		require.Regexp(t, `^[0-9a-f]{40}$`, mapped["sha"])
+		if mapped["backup"] != nil {
+			require.Regexp(t, `^[^@]+@[^@]+$`, mapped["backup"])
+		}
+		if mapped["limit"] != nil {
+			require.Contains(t, []interface{}{1, 2, 3}, mapped["limit"])
+		}
+	}
+}
+
+func TestFakeAttributeErrors(t *testing.T) {
+	Register("test_failing", func(ctx context.Context, r *rand.Rand) (interface{}, error) {
+		return nil, fmt.Errorf("no ids left")
+	})
+
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: `{ data: { id: string @fake(unknown) } }`,
+			err:   "data.id: unknown generator: @fake(unknown)",
+		},
+		{
+			input: `{ id: string @fake(test_failing) }`,
+			err:   "id: no ids left",
+		},
+		{
+			input: `{ count: int @fake(range="1") }`,
+			err:   `count: invalid range: "1"`,
+		},
+		{
+			input: `{ count: int @fake(enum=1|two) }`,
+			err:   `count: strconv.Atoi: parsing "two": invalid syntax`,
+		},
+		{
+			input: `{ sha: string @fake(regex="[0-9") }`,
+			err:   `sha: invalid regex: "[0-9"`,
+		},
+		{
+			input: `{ count: int @fake(regex="[0-9]+") }`,
+			err:   `count: @fake(regex="[0-9]+") is unsupported for int`,
+		},
+		{
+			input: `{ ok: bool @fake(email) }`,
+			err:   "ok: @fake(email) is unsupported for bool",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r := &cue.Runtime{}
+			inst, err := r.Compile(".", test.input)
+			require.NoError(t, err)
+
+			_, err = Fake(context.Background(), inst.Value())
+			require.EqualError(t, err, test.err)
+			ferr := &FieldError{}
+			require.True(t, errors.As(err, &ferr))
+		})
+	}
+}
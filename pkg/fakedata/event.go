@@ -0,0 +1,98 @@
+package fakedata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cuelang.org/go/cue"
+	"github.com/inngest/event-schemas/events"
+)
+
+// maxEventAge is the maximum age of the timestamp of events generated by
+// FakeEvent.
+const maxEventAge = 7 * 24 * time.Hour
+
+// Event is a complete Inngest event, as sent to Inngest.  It marshals directly
+// to the event's JSON payload.
+type Event struct {
+	Name string                 `json:"name"`
+	Data map[string]interface{} `json:"data"`
+	User map[string]interface{} `json:"user"`
+	// Timestamp is the epoch of the event, in milliseconds.
+	Timestamp int64  `json:"ts"`
+	Version   string `json:"v,omitempty"`
+}
+
+// FakeEvent generates a complete fake event for a registered event, eg.
+// events.DefaultRegistry.Get("github/pull_request").  The event's name and
+// version are those of the definition, its data is generated from the
+// definition and any @fake attributes it contains, and its timestamp is within
+// the week before Options.Now.  Dates and times within the data precede the
+// event's timestamp.
+//
+// If the definition doesn't specify any user fields, the event's user
+// contains a fake external ID and email.  Fields which allow any value, eg.
+// `_`, are null so that the event is complete.
+func FakeEvent(ctx context.Context, evt events.Event, o Options) (Event, error) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(evt.Name, evt.Cue)
+	if err != nil {
+		return Event{}, fmt.Errorf("error compiling %s: %w", evt.Name, err)
+	}
+	return FakeEventValue(ctx, evt, inst.Value(), o)
+}
+
+// FakeEventValue generates a complete fake event for a registered event, as
+// FakeEvent does, using the given value as the event's definition in place of
+// evt.Cue.
+func FakeEventValue(ctx context.Context, evt events.Event, v cue.Value, o Options) (Event, error) {
+	// Share a single random source when generating the event's data, user
+	// and timestamp, so that the same seed always generates the same event.
+	o.rand = nil
+	o = o.withRand()
+	o.TopNull = true
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	ts := o.Now.Add(-time.Duration(o.rand.Int63n(int64(maxEventAge))))
+	o.Now = ts
+
+	val, err := fakeValue(ctx, v, o)
+	if err != nil {
+		return Event{}, err
+	}
+
+	// Round trip via JSON rather than decoding the value directly, so that
+	// empty lists remain lists.
+	byt, err := val.MarshalJSON()
+	if err != nil {
+		return Event{}, fmt.Errorf("error encoding fake data: %w", err)
+	}
+	payload := struct {
+		Data map[string]interface{} `json:"data"`
+		User map[string]interface{} `json:"user"`
+	}{}
+	if err := json.Unmarshal(byt, &payload); err != nil {
+		return Event{}, fmt.Errorf("error decoding fake data: %w", err)
+	}
+
+	out := Event{
+		Name:      evt.Name,
+		Data:      payload.Data,
+		User:      payload.User,
+		Timestamp: ts.UnixMilli(),
+		Version:   evt.Version,
+	}
+	if out.Data == nil {
+		out.Data = map[string]interface{}{}
+	}
+	if len(out.User) == 0 {
+		out.User = map[string]interface{}{
+			"external_id": Generate(ctx, KindString, o, Constraint{Rule: RuleFormat, Value: FormatUUID}),
+			"email":       Generate(ctx, KindString, o, Constraint{Rule: RuleFormat, Value: FormatEmail}),
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package fakedata
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/inngest/event-schemas/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeEvent(t *testing.T) {
+	evt, ok := events.DefaultRegistry.Get("github/pull_request")
+	require.True(t, ok)
+
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	o := DefaultOptions
+	o.Seed = 3
+	o.Now = now
+
+	fake, err := FakeEvent(context.Background(), evt, o)
+	require.NoError(t, err)
+	require.Equal(t, "github/pull_request", fake.Name)
+	require.Contains(t, fake.Data, "pull_request")
+	require.Contains(t, fake.User, "external_id")
+	require.Contains(t, fake.User, "email")
+
+	// Timestamps are recent epochs in milliseconds.
+	ts := time.UnixMilli(fake.Timestamp)
+	require.False(t, ts.After(now))
+	require.True(t, ts.After(now.Add(-maxEventAge)))
+
+	// Times within the data precede the event.
+	created, err := time.Parse(time.RFC3339, fake.Data["pull_request"].(map[string]interface{})["created_at"].(string))
+	require.NoError(t, err)
+	require.False(t, created.After(ts))
+	require.True(t, created.After(ts.Add(-maxTimeAge-time.Second)))
+
+	// The same seed generates an identical event.
+	again, err := FakeEvent(context.Background(), evt, o)
+	require.NoError(t, err)
+	require.Equal(t, fake, again)
+
+	// The event marshals directly to JSON, and satisfies the definition.
+	require.NoError(t, evt.Validate(payload(t, fake)))
+}
+
+func TestFakeEventsValidate(t *testing.T) {
+	// Every registered event generates complete events, including fields
+	// which allow any value.
+	for _, evt := range events.DefaultRegistry.All() {
+		for seed := int64(0); seed < 3; seed++ {
+			o := DefaultOptions
+			o.Seed = seed
+			fake, err := FakeEvent(context.Background(), evt, o)
+			require.NoError(t, err, evt.Name)
+			require.NoError(t, evt.Validate(payload(t, fake)), evt.Name)
+		}
+	}
+}
+
+// payload returns the event's JSON payload.
+func payload(t *testing.T, evt Event) map[string]interface{} {
+	t.Helper()
+	byt, err := json.Marshal(evt)
+	require.NoError(t, err)
+	payload := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(byt, &payload))
+	return payload
+}
+
+func TestFakeEventVersion(t *testing.T) {
+	evt := events.Event{
+		Name:    "acme/signup",
+		Version: "2",
+		Cue: `{
+			name: "acme/signup"
+			data: { plan: "free" | "pro" }
+			user: { id: string }
+			v: "2"
+		}`,
+	}
+
+	fake, err := FakeEvent(context.Background(), evt, DefaultOptions)
+	require.NoError(t, err)
+	require.Equal(t, "2", fake.Version)
+	require.Contains(t, []interface{}{"free", "pro"}, fake.Data["plan"])
+
+	// Users from the definition are kept as-is.
+	require.Len(t, fake.User, 1)
+	require.Contains(t, fake.User, "id")
+}
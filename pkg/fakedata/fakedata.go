@@ -14,6 +14,7 @@ import (
 	"cuelang.org/go/cue/format"
 	"cuelang.org/go/cue/token"
 	"cuelang.org/go/encoding/openapi"
+	"github.com/inngest/event-schemas/pkg/formats"
 )
 
 var (
@@ -23,47 +24,158 @@ var (
 
 	// ctxPath lets us store the paths we've walked to figure out the constraints.
 	ctxPath = "path"
+
+	// ctxNoPredict disables predicting formats from field names, when
+	// regenerating fields whose predicted format may be invalid.
+	ctxNoPredict = "noPredict"
 )
 
-func NewRand() *rand.Rand {
-	return rand.New(rand.NewSource(time.Now().UnixNano()))
-}
+// Epoch is the time which generated dates and times precede when Options.Now
+// isn't set, so that the same seed always generates the same data.
+var Epoch = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
 
+// DefaultOptions are the options used by Fake.
 var DefaultOptions = Options{
-	Rand:           NewRand(),
 	FloatPrecision: 2,
 	NumericBound:   1 << 16,
+	MinListLength:  1,
+	MaxListLength:  3,
+	MaxRetries:     10,
 }
 
 type Options struct {
+	// Seed seeds the random source for each call to FakeWithOptions.  Calls
+	// with the same seed, options and definition generate identical data.
+	Seed int64
+	// Rand is the random source, used in place of Seed if set.
+	//
+	// Deprecated: Use Seed.  Rand isn't safe for concurrent use, so calls
+	// sharing Rand must not run concurrently, and generate different data
+	// for the same options.
 	Rand *rand.Rand
 	// FloatPrecision is the max number of decimal places to generate for
 	// number or float datatypes.
 	FloatPrecision int
 	// NumericBound is the upper and lower numeric bound for random numbers
 	NumericBound int
+	// MinListLength and MaxListLength are the inclusive bounds for the
+	// length of generated lists, unless the list's definition requires
+	// otherwise.
+	MinListLength int
+	MaxListLength int
+	// Now is the time which generated dates and times precede, defaulting
+	// to Epoch.  FakeEvent defaults this to the current time, and generates
+	// timestamps within the week before Now;  set this to generate identical
+	// events for the same seed.
+	Now time.Time
+	// MaxRetries is the number of times fields whose data doesn't satisfy
+	// their definition are regenerated, eg. when a field's name suggests a
+	// format that conflicts with its constraints.
+	MaxRetries int
+	// TopNull generates null for fields which allow any value, eg. `_`, so
+	// that the generated data is concrete.  Otherwise, these fields are
+	// omitted.
+	TopNull bool
+	// NonNegative generates numbers within [0, NumericBound], rather than
+	// [-NumericBound, NumericBound], unless a field's definition requires
+	// negative numbers.
+	NonNegative bool
+
+	// rand is the random source for a single call, created from Seed.  This
+	// is never shared between calls, as *rand.Rand isn't safe for concurrent
+	// use.
+	rand *rand.Rand
+}
+
+// NewRand returns a random source seeded with the current time.
+//
+// Deprecated: Use Options.Seed.
+func NewRand() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// withRand returns a copy of the options with a new random source created
+// from the seed, or Rand if set, unless the options already have a random
+// source.
+func (o Options) withRand() Options {
+	if o.rand == nil && o.Rand != nil {
+		o.rand = o.Rand
+	}
+	if o.rand == nil {
+		o.rand = rand.New(rand.NewSource(o.Seed))
+	}
+	return o
 }
 
 // Fake generates fake data for a given cue definition.  The returning cue.Value
 // can be decoded into the corresponding Go type.  For example, if the cue definition
 // represents a string, the returning value can be decoded into a Go string.  Likewise,
 // for maps, we can decode into a map[string]interface{}.
+//
+// Fake generates random data using DefaultOptions.  Use FakeWithOptions to
+// generate reproducible data.
 func Fake(ctx context.Context, v cue.Value) (cue.Value, error) {
-	s := &Output{StructLit: ast.NewStruct()}
-
 	o := DefaultOptions
-	if o.Rand == nil {
-		o.Rand = NewRand()
-	}
+	o.Seed = time.Now().UnixNano()
+	return FakeWithOptions(ctx, v, o)
+}
+
+// FakeWithOptions generates fake data for a given cue definition using the
+// given options.  The output is deterministic:  calls with the same seed and
+// definition generate identical data.  Copy DefaultOptions and set the seed
+// to use the default options, eg:
+//
+//	o := fakedata.DefaultOptions
+//	o.Seed = 1
+//	val, err := fakedata.FakeWithOptions(ctx, v, o)
+func FakeWithOptions(ctx context.Context, v cue.Value, o Options) (cue.Value, error) {
+	// Create a new random source for every call, so that calls are
+	// reproducible and safe to run concurrently.
+	o.rand = nil
+	return fakeValue(ctx, v, o.withRand())
+}
+
+// fakeValue generates fake data for a given cue definition using the options'
+// random source.  The fake data is unified with the definition, and fields
+// which don't satisfy the definition are regenerated up to Options.MaxRetries
+// times before returning a *ValidationError.
+func fakeValue(ctx context.Context, v cue.Value, o Options) (cue.Value, error) {
+	s := &Output{StructLit: ast.NewStruct()}
 
 	// Iterate through the value, adding each field to the struct output.
-	if err := walk(ctx, v, s.StructLit, DefaultOptions); err != nil {
+	if err := walk(ctx, v, s.StructLit, o); err != nil {
 		return cue.Value{}, err
 	}
 
+	for attempt := 0; ; attempt++ {
+		val, err := compile(s.StructLit)
+		if err != nil {
+			return cue.Value{}, err
+		}
+
+		invalid := validate(v, val)
+		if len(invalid) == 0 {
+			return val, nil
+		}
+		if attempt >= o.MaxRetries {
+			return cue.Value{}, &ValidationError{Fields: invalid}
+		}
+
+		// The field's name may suggest a format which doesn't satisfy its
+		// definition, so regenerate fields without predicting formats.
+		for _, field := range invalid {
+			if err := regenerate(context.WithValue(ctx, ctxNoPredict, true), v, s.StructLit, field.segments, o); err != nil {
+				return cue.Value{}, err
+			}
+		}
+	}
+}
+
+// compile compiles the generated cue struct.
+func compile(s *ast.StructLit) (cue.Value, error) {
 	// Format the output.
 	byt, err := format.Node(
-		s.StructLit,
+		s,
 		format.TabIndent(false),
 		format.UseSpaces(2),
 	)
@@ -92,7 +204,7 @@ func walk(ctx context.Context, v cue.Value, to *ast.StructLit, o Options) (err e
 	op, exprVals := v.Expr()
 	if op == cue.OrOp {
 		// Choose one of the epressions to use randomly.
-		i := o.Rand.Intn(len(exprVals))
+		i := o.rand.Intn(len(exprVals))
 		v = exprVals[i]
 	}
 
@@ -107,70 +219,234 @@ func walk(ctx context.Context, v cue.Value, to *ast.StructLit, o Options) (err e
 		}
 
 		// An optional field has a 50% chance of being generated.
-		if it.IsOptional() && o.Rand.Intn(2) == 1 {
+		if it.IsOptional() && o.rand.Intn(2) == 1 {
 			continue
 		}
 
 		nestedCtx := withPath(ctx, it.Label())
 
-		val := it.Value()
-		label := it.Label()
+		expr, ok, err := fake(nestedCtx, it.Value(), o)
+		if err != nil {
+			return err
+		}
+		if !ok && o.TopNull && it.Value().IncompleteKind() == cue.TopKind {
+			expr, ok = ast.NewNull(), true
+		}
+		if ok {
+			set(to, it.Label(), expr)
+		}
+	}
+
+	return err
+}
 
-		switch val.IncompleteKind() {
-		case cue.BoolKind:
-			t := "false"
-			if generatorFunc(ctx, KindBool, o) == true {
-				t = "true"
+// fake returns cue AST representing fake data for the given value, or false if
+// we can't generate data for the value, eg. for top (`_`).
+func fake(ctx context.Context, val cue.Value, o Options) (ast.Expr, bool, error) {
+	// Fields may declare how to generate their data via attributes.
+	if attr := val.Attribute(attrName); attr.Err() == nil {
+		return fakeAttr(ctx, val, attr, o)
+	}
+
+	kind := val.IncompleteKind()
+
+	// Lists constrained via the list package, eg. `[...int] & list.MinItems(2)`,
+	// are bottom until they contain enough items, so check for a list
+	// regardless.
+	if kind == cue.ListKind || kind == cue.BottomKind {
+		if l, ok := listConstraints(val); ok {
+			return genList(ctx, l, o)
+		}
+	}
+
+	switch kind {
+	case cue.BoolKind:
+		t := "false"
+		if generatorFunc(ctx, KindBool, o) == true {
+			t = "true"
+		}
+		return ast.NewLit(token.STRING, t), true, nil
+	case cue.StringKind:
+		lit, err := genString(ctx, val, o)
+		return lit, err == nil, err
+	case cue.NumberKind, cue.FloatKind:
+		lit, err := genNumber(ctx, KindFloat, val, o)
+		return lit, err == nil, err
+	case cue.IntKind:
+		lit, err := genNumber(ctx, KindInt, val, o)
+		return lit, err == nil, err
+	case cue.NullKind:
+		return ast.NewNull(), true, nil
+	case cue.StructKind:
+		// Create a new struct and iterate into the struct, walking through
+		// its fields.
+		inner := ast.NewStruct()
+		if err := walk(ctx, val, inner, o); err != nil {
+			return nil, false, err
+		}
+		return inner, true, nil
+	}
+
+	// This may be a disjunction of different kinds, eg. `string | null`.  If
+	// so, generate one of the disjunction's values at random.
+	if op, exprVals := val.Expr(); op == cue.OrOp {
+		i := o.rand.Intn(len(exprVals))
+		return fake(ctx, exprVals[i], o)
+	}
+
+	// Can't do this one, homie.
+	return nil, false, nil
+}
+
+// list represents the constraints of a list definition.
+type list struct {
+	// val is the list type, eg. `[string, ...int]`.
+	val cue.Value
+	// minItems and maxItems are the bounds specified via list.MinItems and
+	// list.MaxItems, or -1 if unspecified.
+	minItems int
+	maxItems int
+}
+
+// listConstraints returns the list type and length constraints of a list
+// definition, or false if the value isn't a list.
+func listConstraints(val cue.Value) (list, bool) {
+	l := list{minItems: -1, maxItems: -1}
+	found := false
+
+	var collect func(v cue.Value)
+	collect = func(v cue.Value) {
+		op, exprVals := v.Expr()
+		switch op {
+		case cue.AndOp:
+			for _, item := range exprVals {
+				collect(item)
+			}
+		case cue.CallOp:
+			// Calls to list.MinItems(n) and list.MaxItems(n) bound the
+			// list's length.
+			if len(exprVals) != 2 {
+				return
 			}
-			lit := ast.NewLit(token.STRING, t)
-			set(to, label, lit)
-		case cue.StringKind:
-			lit := genString(nestedCtx, val, o)
-			set(to, label, lit)
-		case cue.NumberKind, cue.FloatKind:
-			lit := genNumber(nestedCtx, KindFloat, val, o)
-			set(to, label, lit)
-		case cue.IntKind:
-			lit := genNumber(nestedCtx, KindInt, val, o)
-			set(to, label, lit)
-		case cue.StructKind:
-			// Create a new struct and set the field to the new struct
-			inner := ast.NewStruct()
-			set(to, label, inner)
-			// Iterate into the struct and walk through those fields,
-			// setting where necessary
-			if err := walk(nestedCtx, val, inner, o); err != nil {
-				return err
+			selOp, sel := exprVals[0].Expr()
+			if selOp != cue.SelectorOp || len(sel) != 2 {
+				return
+			}
+			name, _ := sel[1].String()
+			n, err := exprVals[1].Int64()
+			if err != nil {
+				return
+			}
+			switch name {
+			case "MinItems":
+				l.minItems = int(n)
+			case "MaxItems":
+				l.maxItems = int(n)
+			}
+		case cue.NoOp:
+			if !found && v.IncompleteKind() == cue.ListKind {
+				l.val = v
+				found = true
 			}
-		default:
-			// Can't do this one, homie.
 		}
 	}
+	collect(val)
 
-	return err
+	return l, found
+}
+
+// genList returns cue AST representing a list.  Lists contain each of the
+// list type's fixed elements, followed by elements of the list type's
+// element type (eg. `int` for `[...int]`) until the list is between
+// Options.MinListLength and Options.MaxListLength long, within the bounds of
+// list.MinItems and list.MaxItems.
+func genList(ctx context.Context, l list, o Options) (ast.Expr, bool, error) {
+	exprs := []ast.Expr{}
+
+	it, err := l.val.List()
+	if err != nil {
+		return nil, false, err
+	}
+	for it.Next() {
+		expr, ok, err := fake(ctx, it.Value(), o)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			return nil, false, nil
+		}
+		exprs = append(exprs, expr)
+	}
+
+	// Closed lists, eg. `[string, int]`, only contain their fixed elements.
+	elem := l.val.LookupPath(cue.MakePath(cue.AnyIndex))
+	if !elem.Exists() {
+		return ast.NewList(exprs...), true, nil
+	}
+
+	// The list's bounds take precedence over the options' lengths, so that
+	// eg. MinListLength doesn't exceed list.MaxItems.
+	min, max := o.MinListLength, o.MaxListLength
+	if l.maxItems >= 0 && l.maxItems < min {
+		min = l.maxItems
+	}
+	if l.maxItems >= 0 && l.maxItems < max {
+		max = l.maxItems
+	}
+	if l.minItems > min {
+		min = l.minItems
+	}
+	if len(exprs) > min {
+		min = len(exprs)
+	}
+	if max < min {
+		max = min
+	}
+
+	n := min + o.rand.Intn(max-min+1)
+	for len(exprs) < n {
+		expr, ok, err := fake(ctx, elem, o)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			// We can't generate elements such as top, eg. `[...]`.
+			break
+		}
+		exprs = append(exprs, expr)
+	}
+
+	return ast.NewList(exprs...), true, nil
 }
 
 // genString returns cue AST representing a string
-func genString(ctx context.Context, val cue.Value, o Options) *ast.BasicLit {
-	constraints := constraints(ctx, KindString, val)
+func genString(ctx context.Context, val cue.Value, o Options) (*ast.BasicLit, error) {
+	constraints, err := constraints(ctx, KindString, o, val)
+	if err != nil {
+		return nil, &FieldError{Path: path(ctx), Err: err}
+	}
 	f := generatorFunc(ctx, KindString, o, constraints...)
-	return ast.NewLit(token.STRING, strconv.Quote(f.(string)))
+	return ast.NewLit(token.STRING, strconv.Quote(f.(string))), nil
 }
 
 // genNumber returns cue AST representing a float or int, depending on the
 // kind passed as an argument.
-func genNumber(ctx context.Context, k Kind, val cue.Value, o Options) *ast.BasicLit {
+func genNumber(ctx context.Context, k Kind, val cue.Value, o Options) (*ast.BasicLit, error) {
 	if k != KindInt && k != KindFloat {
-		return nil
+		return nil, fmt.Errorf("unknown numeric kind: %s", k)
+	}
+	constraints, err := constraints(ctx, k, o, val)
+	if err != nil {
+		return nil, &FieldError{Path: path(ctx), Err: err}
 	}
-	f := generatorFunc(ctx, k, o, constraints(ctx, k, val)...)
+	f := generatorFunc(ctx, k, o, constraints...)
 
 	if k == KindFloat {
 		byt, _ := json.Marshal(f)
-		return ast.NewLit(token.FLOAT, fmt.Sprintf("%s", string(byt)))
+		return ast.NewLit(token.FLOAT, fmt.Sprintf("%s", string(byt))), nil
 	}
 
-	return ast.NewLit(token.INT, fmt.Sprintf("%d", f.(int)))
+	return ast.NewLit(token.INT, fmt.Sprintf("%d", f.(int))), nil
 }
 
 // constraints returns data generation constraints given a cue value
@@ -178,8 +454,9 @@ func genNumber(ctx context.Context, k Kind, val cue.Value, o Options) *ast.Basic
 // any additional constraints added via types to generate all constraints,
 // eg. `uint8 & >= 5`.
 //
-// This turns nested cue operators to constraints for our generator.
-func constraints(ctx context.Context, kind Kind, vals ...cue.Value) []Constraint {
+// This turns nested cue operators to constraints for our generator.  Values
+// which can't be decoded as the given kind return an error.
+func constraints(ctx context.Context, kind Kind, o Options, vals ...cue.Value) ([]Constraint, error) {
 	c := []Constraint{}
 
 	for _, val := range vals {
@@ -200,7 +477,7 @@ func constraints(ctx context.Context, kind Kind, vals ...cue.Value) []Constraint
 					// This is a value, which indicates a constraint.
 					value, err := decode(kind, val)
 					if err != nil {
-						panic(err.Error())
+						return nil, err
 					}
 					c = append(c, Constraint{Rule: RuleEq, Value: value})
 					node = nil
@@ -215,57 +492,146 @@ func constraints(ctx context.Context, kind Kind, vals ...cue.Value) []Constraint
 
 		case cue.OrOp:
 			// Iterate through each element in the union and figure out constraints from there.
-			next := constraints(ctx, kind, exprVals...)
+			next, err := constraints(ctx, kind, o, exprVals...)
+			if err != nil {
+				return nil, err
+			}
 			// Next are constraints with "RuleEq" values.  The value is an interface representing
 			// the element we must match.
 			value := []interface{}{}
+			literals := true
 			for _, constraint := range next {
-				if constraint.Rule != RuleEq {
-					// TODO (tonyhb): remove panic.
-					panic(fmt.Errorf("unknown value"))
+				switch constraint.Rule {
+				case RuleEq:
+					value = append(value, constraint.Value)
+				case RuleFormat:
+					// Formats predicted from the field's name don't apply
+					// to literals.
+				default:
+					literals = false
 				}
-				value = append(value, constraint.Value)
 			}
-			c = append(c, Constraint{Rule: RuleOneOf, Value: value})
+			if literals {
+				c = append(c, Constraint{Rule: RuleOneOf, Value: value})
+				break
+			}
+
+			// This is a union of constraints, eg. `<1 | >10`, so use the
+			// constraints of one of the union's elements.
+			next, err = constraints(ctx, kind, o, exprVals[o.rand.Intn(len(exprVals))])
+			if err != nil {
+				return nil, err
+			}
+			c = append(c, next...)
 		case cue.AndOp:
 			// If there's > 1 value within this constraint, we know that it's combined
 			// with more constraints (eg. uint& & >= 5).  In this example,  we want
 			// to recurse and add the constraints from these to our collecton.
-			c = append(c, constraints(ctx, kind, exprVals...)...)
+			next, err := constraints(ctx, kind, o, exprVals...)
+			if err != nil {
+				return nil, err
+			}
+			c = append(c, next...)
 
 		case cue.EqualOp:
-			decoded := mustDecode(kind, exprVals[0])
+			decoded, err := decode(kind, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
 			c = append(c, Constraint{Rule: RuleEq, Value: decoded})
 
 		case cue.NotEqualOp, cue.NotOp:
-			decoded := mustDecode(kind, exprVals[0])
+			decoded, err := decode(kind, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
 			c = append(c, Constraint{Rule: RuleNEq, Value: decoded})
 
 		case cue.LessThanOp:
-			decoded := mustDecode(kind, exprVals[0])
+			decoded, err := decode(kind, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
 			c = append(c, Constraint{Rule: RuleLT, Value: decoded})
 
 		case cue.LessThanEqualOp:
-			decoded := mustDecode(kind, exprVals[0])
+			decoded, err := decode(kind, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
 			c = append(c, Constraint{Rule: RuleLTE, Value: decoded})
 
 		case cue.GreaterThanEqualOp:
-			decoded := mustDecode(kind, exprVals[0])
+			decoded, err := decode(kind, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
 			c = append(c, Constraint{Rule: RuleGTE, Value: decoded})
 
 		case cue.GreaterThanOp:
-			decoded := mustDecode(kind, exprVals[0])
+			decoded, err := decode(kind, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
 			c = append(c, Constraint{Rule: RuleGT, Value: decoded})
+
+		case cue.RegexMatchOp:
+			// Regular expressions for well-known formats, eg. those inferred
+			// from JSON, map directly to a format.
+			decoded, err := decode(KindString, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
+			pattern := decoded.(string)
+			if f, ok := formats.FromPattern(pattern); ok {
+				c = append(c, Constraint{Rule: RuleFormat, Value: knownFormats[f]})
+				break
+			}
+			c = append(c, Constraint{Rule: RuleRegex, Value: pattern})
+
+		case cue.NotRegexMatchOp:
+			decoded, err := decode(KindString, exprVals[0])
+			if err != nil {
+				return nil, err
+			}
+			c = append(c, Constraint{Rule: RuleNotRegex, Value: decoded})
 		}
 	}
 
 	// If this is of kind string, attempt to ascertain the expected output for
-	// fake data generation based off of the path.
-	if kind == KindString {
+	// fake data generation based off of the path, unless the value is already
+	// constrained to a format or regular expression.
+	if noPredict, _ := ctx.Value(ctxNoPredict).(bool); noPredict {
+		return c, nil
+	}
+	if kind == KindString && !hasRule(c, RuleFormat) && !hasRule(c, RuleRegex) {
 		c = append(c, predictStringFormats(ctx)...)
 	}
+	if kind == KindInt || kind == KindFloat {
+		c = append(c, predictNumberFormats(ctx)...)
+	}
 
-	return c
+	return c, nil
+}
+
+// knownFormats maps well-known string formats to the format generated.
+var knownFormats = map[formats.Format]Format{
+	formats.DateTime: FormatTime,
+	formats.UUID:     FormatUUID,
+	formats.Email:    FormatEmail,
+	formats.URL:      FormatURL,
+	formats.IPv4:     FormatIPv4,
+	formats.IPv6:     FormatIPv6,
+}
+
+// hasRule returns whether any of the constraints use the given rule.
+func hasRule(c []Constraint, r Rule) bool {
+	for _, item := range c {
+		if item.Rule == r {
+			return true
+		}
+	}
+	return false
 }
 
 func predictStringFormats(ctx context.Context) []Constraint {
@@ -292,15 +658,16 @@ func predictStringFormats(ctx context.Context) []Constraint {
 	if strings.Contains(field, "_ip") || strings.Contains(field, "ip_") {
 		return []Constraint{{Rule: RuleFormat, Value: FormatIPv4}}
 	}
+	if strings.Contains(field, "time") || strings.HasSuffix(field, "_at") {
+		// This precedes dates, as fields such as "updated_at" are times.
+		return []Constraint{{Rule: RuleFormat, Value: FormatTime}}
+	}
 	if strings.Contains(field, "date") {
 		return []Constraint{{Rule: RuleFormat, Value: FormatDate}}
 	}
 	if strings.Contains(field, "name") {
 		return []Constraint{{Rule: RuleFormat, Value: FormatName}}
 	}
-	if strings.Contains(field, "time") || strings.HasSuffix(field, "at") {
-		return []Constraint{{Rule: RuleFormat, Value: FormatTime}}
-	}
 	if strings.Contains(field, "title") {
 		return []Constraint{{Rule: RuleFormat, Value: FormatTitle}}
 	}
@@ -311,12 +678,17 @@ func predictStringFormats(ctx context.Context) []Constraint {
 	return nil
 }
 
-func mustDecode(k Kind, v cue.Value) interface{} {
-	d, err := decode(k, v)
-	if err != nil {
-		panic(err)
+// predictNumberFormats returns the format of a number based on the field's
+// name, eg. timestamps for "created_at".
+func predictNumberFormats(ctx context.Context) []Constraint {
+	parts := strings.Split(path(ctx), ".")
+	field := strings.ToLower(parts[len(parts)-1])
+
+	switch {
+	case strings.HasSuffix(field, "_at"), field == "created", field == "updated", field == "timestamp":
+		return []Constraint{{Rule: RuleFormat, Value: FormatTimestamp}}
 	}
-	return d
+	return nil
 }
 
 // decode decodes a cue value with a given kind, allowing us to type convert
@@ -338,8 +710,12 @@ func decode(k Kind, v cue.Value) (interface{}, error) {
 		}
 		return i, nil
 	case KindFloat:
-		var f float64
-		err := v.Decode(&f)
+		f, err := v.Float64()
+		if f == 0 && (err == cue.ErrBelow || err == cue.ErrAbove) {
+			// cue reports zero as rounded, as it's smaller than the smallest
+			// positive float64.
+			err = nil
+		}
 		return f, err
 	}
 	return nil, fmt.Errorf("not implemented")
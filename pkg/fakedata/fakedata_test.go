@@ -4,15 +4,21 @@ import (
 	"context"
 	"math"
 	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"cuelang.org/go/cue"
+	_ "cuelang.org/go/pkg"
 	"github.com/stretchr/testify/require"
 )
 
 func TestFakeConstraints(t *testing.T) {
 	rand.Seed(time.Now().UnixNano())
+	defer func() { generatorFunc = Generate }()
 
 	tests := []struct {
 		input       string
@@ -171,6 +177,56 @@ func TestFakeConstraints(t *testing.T) {
 				},
 			},
 		},
+		// well-known formats take precedence over the field name.
+		{
+			input: `{ email: string & =~"^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$" }`,
+			constraints: map[string][]Constraint{
+				"email": {
+					{
+						Rule:  RuleFormat,
+						Value: FormatUUID,
+					},
+				},
+			},
+		},
+		{
+			input: `{ created: string & =~"^\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}(\\.\\d+)?(Z|[+-]\\d{2}:\\d{2})$" }`,
+			constraints: map[string][]Constraint{
+				"created": {
+					{
+						Rule:  RuleFormat,
+						Value: FormatTime,
+					},
+				},
+			},
+		},
+		// other regular expressions are generated from the expression.
+		{
+			input: `{ id: string & =~"^ch_[A-Za-z0-9]{24}$" }`,
+			constraints: map[string][]Constraint{
+				"id": {
+					{
+						Rule:  RuleRegex,
+						Value: "^ch_[A-Za-z0-9]{24}$",
+					},
+				},
+			},
+		},
+		{
+			input: `{ email: string & !~"@example\\.com$" }`,
+			constraints: map[string][]Constraint{
+				"email": {
+					{
+						Rule:  RuleNotRegex,
+						Value: "@example\\.com$",
+					},
+					{
+						Rule:  RuleFormat,
+						Value: FormatEmail,
+					},
+				},
+			},
+		},
 	}
 
 	for _, item := range tests {
@@ -207,7 +263,7 @@ func TestFakeData(t *testing.T) {
 			`,
 			seed: 1274,
 			expected: map[string]interface{}{
-				"something": map[string]interface{}{"phone": "762-531-0198"},
+				"something": map[string]interface{}{"phone": "820-989-7606"},
 			},
 		},
 		{
@@ -226,12 +282,12 @@ func TestFakeData(t *testing.T) {
 			seed: 2,
 			expected: map[string]interface{}{
 				"name":        "foo",
-				"email":       "EJJvgRK@GlkqMrj.biz",
+				"email":       "illud@hiab.net",
 				"enum":        "what",
-				"website_url": "http://www.DrHmnxY.net/fDJEeNT",
-				"ok":          false,
+				"website_url": "http://www.piamubi.com/perdita",
+				"ok":          true,
 				"data": map[string]interface{}{
-					"number": 8.44,
+					"number": 1.11,
 				},
 			},
 		},
@@ -251,12 +307,12 @@ func TestFakeData(t *testing.T) {
 			seed: 772142,
 			expected: map[string]interface{}{
 				"name":        "foo",
-				"email":       "LdXPYli@sbUWRVi.info",
+				"email":       "miser@iudiciacibus.net",
 				"enum":        "what",
-				"website_url": "http://PbPaKRh.biz/WJMTmsf.php",
+				"website_url": "http://www.ullosumus.com/ac/graecae.html",
 				"ok":          false,
 				"data": map[string]interface{}{
-					"number": 5.52,
+					"number": 8.38,
 				},
 			},
 		},
@@ -283,8 +339,9 @@ func TestFakeData(t *testing.T) {
 			require.NoError(t, err)
 
 			// Set the seed for deterministic testing
-			DefaultOptions.Rand = rand.New(rand.NewSource(test.seed))
-			output, err := Fake(context.Background(), inst.Value())
+			o := DefaultOptions
+			o.Seed = test.seed
+			output, err := FakeWithOptions(context.Background(), inst.Value(), o)
 
 			require.NoError(t, err)
 			mapped := map[string]interface{}{}
@@ -294,3 +351,238 @@ func TestFakeData(t *testing.T) {
 		})
 	}
 }
+
+func TestFakeLists(t *testing.T) {
+	input := `
+	import "list"
+
+	{
+		strings: [...string]
+		bounded: [...int] & list.MinItems(4) & list.MaxItems(5)
+		capped:  [...int] & list.MaxItems(1)
+		none:    [...string] & list.MaxItems(0)
+		tuple:   [string, int]
+		prefix:  [bool, ...string]
+		structs: [...{id: string, count: int}]
+		unions:  [...(string | {ok: bool})]
+		nested:  [...[...int]]
+		unknown: [...]
+		empty:   []
+		nullable: [...string] | null
+		null:    null
+		scalar:  string | int
+	}
+	`
+
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", input)
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+
+		mapped := map[string]interface{}{}
+		require.NoError(t, output.Decode(&mapped))
+
+		length := func(field string) int {
+			return len(mapped[field].([]interface{}))
+		}
+		require.GreaterOrEqual(t, length("strings"), DefaultOptions.MinListLength)
+		require.LessOrEqual(t, length("strings"), DefaultOptions.MaxListLength)
+		require.GreaterOrEqual(t, length("bounded"), 4)
+		require.LessOrEqual(t, length("bounded"), 5)
+		require.Equal(t, 1, length("capped"))
+		require.Equal(t, 0, length("none"))
+		require.Equal(t, 2, length("tuple"))
+		require.IsType(t, "", mapped["tuple"].([]interface{})[0])
+		require.IsType(t, bool(false), mapped["prefix"].([]interface{})[0])
+		require.Equal(t, 0, length("unknown"))
+		require.Equal(t, 0, length("empty"))
+		require.Contains(t, mapped, "null")
+		require.Nil(t, mapped["null"])
+		require.Contains(t, mapped, "nullable")
+		require.Contains(t, mapped, "scalar")
+
+		for _, item := range mapped["structs"].([]interface{}) {
+			require.Contains(t, item, "id")
+			require.Contains(t, item, "count")
+		}
+
+		// Every generated value must satisfy the definition.
+		unified := inst.Value().Unify(output)
+		require.NoError(t, unified.Validate(cue.Concrete(true)), "%v", mapped)
+	}
+}
+
+func TestFakeWithOptions(t *testing.T) {
+	input := `
+	{
+		id: string
+		email: string
+		website_url: string
+		name: string
+		created_at: string
+		count: int & >= 1 & <= 10
+		tags: [...string]
+		kind: "a" | "b"
+		nested?: { ip_addr: string }
+	}
+	`
+
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", input)
+	require.NoError(t, err)
+
+	fake := func(seed int64) []byte {
+		o := DefaultOptions
+		o.Seed = seed
+		output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+		byt, err := output.MarshalJSON()
+		require.NoError(t, err)
+		return byt
+	}
+
+	// The same seed generates identical output.
+	expected := map[int64][]byte{}
+	for seed := int64(0); seed < 10; seed++ {
+		expected[seed] = fake(seed)
+		require.Equal(t, string(expected[seed]), string(fake(seed)))
+	}
+	require.NotEqual(t, string(expected[0]), string(expected[1]))
+
+	// Calls are independent, and so are safe to run concurrently.
+	wg := sync.WaitGroup{}
+	results := make([][]byte, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = fake(int64(i % 10))
+		}(i)
+	}
+	wg.Wait()
+	for i, result := range results {
+		require.Equal(t, string(expected[int64(i%10)]), string(result))
+	}
+}
+
+func TestFakeNumberFormats(t *testing.T) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		created_at: int
+		updated:    number
+		timestamp:  uint8
+		updated_at: string
+		count:      int
+		offset:     int & <0
+	}`)
+	require.NoError(t, err)
+
+	now := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		o.Now = now
+		o.NonNegative = true
+		output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+
+		data := struct {
+			CreatedAt int64   `json:"created_at"`
+			Updated   float64 `json:"updated"`
+			Timestamp int     `json:"timestamp"`
+			UpdatedAt string  `json:"updated_at"`
+			Count     int     `json:"count"`
+			Offset    int     `json:"offset"`
+		}{}
+		require.NoError(t, output.Decode(&data))
+
+		// Numeric timestamps are unix timestamps preceding Now.
+		for _, ts := range []time.Time{time.Unix(data.CreatedAt, 0), time.Unix(int64(data.Updated), 0)} {
+			require.False(t, ts.After(now))
+			require.True(t, ts.After(now.Add(-maxTimeAge-time.Second)))
+		}
+		// Timestamps which don't satisfy the definition are ignored.
+		require.LessOrEqual(t, data.Timestamp, 255)
+		_, err = time.Parse(time.RFC3339, data.UpdatedAt)
+		require.NoError(t, err)
+
+		require.GreaterOrEqual(t, data.Count, 0)
+		require.Less(t, data.Offset, 0)
+	}
+}
+
+func TestFakeWithDeprecatedRand(t *testing.T) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{ id: string, count: int }`)
+	require.NoError(t, err)
+
+	fake := func(o Options) string {
+		output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+		byt, err := output.MarshalJSON()
+		require.NoError(t, err)
+		return string(byt)
+	}
+
+	// Rand takes precedence over Seed.
+	o := DefaultOptions
+	o.Seed = 1
+	o.Rand = rand.New(rand.NewSource(2))
+	seeded := DefaultOptions
+	seeded.Seed = 2
+	require.Equal(t, fake(seeded), fake(o))
+
+	o.Rand = NewRand()
+	require.NotEmpty(t, fake(o))
+}
+
+// TestFakeWithOptionsAcrossProcesses asserts that seeded output doesn't depend
+// on per-process state, such as package-level random sources, by
+// comparing the output of child processes to the output of this process.
+func TestFakeWithOptionsAcrossProcesses(t *testing.T) {
+	input := `
+	{
+		name: string
+		first_name: string
+		email: string
+		website_url: string
+		created_at: string
+		phone: string
+		title: string
+		count: int & >= 1 & <= 10
+	}
+	`
+
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", input)
+	require.NoError(t, err)
+
+	o := DefaultOptions
+	o.Seed = 1
+	output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+	require.NoError(t, err)
+	byt, err := output.MarshalJSON()
+	require.NoError(t, err)
+
+	if out := os.Getenv("FAKEDATA_OUTPUT"); out != "" {
+		require.NoError(t, os.WriteFile(out, byt, 0600))
+		return
+	}
+
+	for i := 0; i < 5; i++ {
+		out := filepath.Join(t.TempDir(), "output.json")
+		cmd := exec.Command(os.Args[0], "-test.run=^TestFakeWithOptionsAcrossProcesses$")
+		cmd.Env = append(os.Environ(), "FAKEDATA_OUTPUT="+out)
+		combined, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(combined))
+
+		actual, err := os.ReadFile(out)
+		require.NoError(t, err)
+		require.Equal(t, string(byt), string(actual))
+	}
+}
@@ -5,10 +5,10 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"regexp"
 	"strings"
 	"time"
 
-	"github.com/bxcodec/faker/v3"
 	"github.com/google/uuid"
 	lorem "github.com/inngest/golorem"
 )
@@ -16,6 +16,13 @@ import (
 const (
 	maxf64 = math.MaxFloat64
 	minf64 = math.MaxFloat64 * -1
+
+	// maxTimeAge is the maximum age of generated dates and times.
+	maxTimeAge = 10 * 365 * 24 * time.Hour
+
+	// maxAttempts is the number of values generated when rejecting values
+	// which don't satisfy their constraints.
+	maxAttempts = 100
 )
 
 type Kind int
@@ -37,7 +44,9 @@ const (
 	RuleEq
 	RuleNEq
 	RuleOneOf
-	RuleFormat // format represents that the string should be in the specified format
+	RuleFormat   // format represents that the string should be in the specified format
+	RuleRegex    // regex represents that the string must match the regular expression
+	RuleNotRegex // not regex represents that the string must not match the regular expression
 )
 
 const (
@@ -51,11 +60,13 @@ const (
 	FormatTime
 	FormatTitle
 	FormatUUID
+	// FormatTimestamp generates numbers as unix timestamps, in seconds.
+	FormatTimestamp
 )
 
 var (
 	kindStrings = []string{"float", "int", "string", "email", "bool"}
-	ruleStrings = []string{"<", "<=", ">", ">=", "==", "!=", "|"}
+	ruleStrings = []string{"<", "<=", ">", ">=", "==", "!=", "|", "format", "=~", "!~"}
 )
 
 func (k Kind) String() string {
@@ -76,9 +87,11 @@ func (c Constraint) String() string {
 	return fmt.Sprintf("%s %v", c.Rule.String(), c.Value)
 }
 
-// Generate generates constraints given a kind, options, and values.
+// Generate generates constraints given a kind, options, and values.  Unless
+// called via FakeWithOptions, this uses a new random source seeded with
+// Options.Seed.
 func Generate(ctx context.Context, kind Kind, o Options, constraints ...Constraint) interface{} {
-	g := generator{kind: kind, o: o, constraints: constraints}
+	g := generator{kind: kind, o: o.withRand(), constraints: constraints}
 	return g.Generate()
 }
 
@@ -95,15 +108,79 @@ func (g generator) Generate() interface{} {
 	case KindString:
 		return g.String()
 	case KindBool:
-		return g.o.Rand.Int31n(2) == 1
+		return g.o.rand.Int31n(2) == 1
 	}
 	return nil
 }
 
+// String generates a string satisfying the constraints.  Strings which don't
+// match every RuleRegex constraint, or which match any RuleNotRegex
+// constraint, are rejected and regenerated up to maxAttempts times, before
+// falling back to other kinds of strings.
 func (g generator) String() string {
+	match, notMatch := []*regexp.Regexp{}, []*regexp.Regexp{}
+	for _, c := range g.constraints {
+		switch c.Rule {
+		case RuleRegex:
+			if re, err := regexp.Compile(c.Value.(string)); err == nil {
+				match = append(match, re)
+			}
+		case RuleNotRegex:
+			if re, err := regexp.Compile(c.Value.(string)); err == nil {
+				notMatch = append(notMatch, re)
+			}
+		}
+	}
+
+	var s string
+	for i := 0; i < maxAttempts; i++ {
+		s = g.string()
+		if matchesAll(s, match) && !matchesAny(s, notMatch) {
+			return s
+		}
+	}
+
+	// Strings from the same generator may never satisfy the constraints, eg.
+	// sentences for `!~"^[A-Z]"`, so try other kinds of strings.
+	for _, pattern := range fallbackPatterns {
+		for i := 0; i < maxAttempts/len(fallbackPatterns); i++ {
+			f, err := regexString(g.o.rand, pattern)
+			if err == nil && matchesAll(f, match) && !matchesAny(f, notMatch) {
+				return f
+			}
+		}
+	}
+	return s
+}
+
+// fallbackPatterns generate strings when the constraints' generator can't
+// satisfy RuleRegex and RuleNotRegex constraints.
+var fallbackPatterns = []string{
+	`[a-z]{4,12}( [a-z]{2,10}){0,5}`,
+	`[a-z][a-z0-9_-]{3,15}`,
+	`[A-Z][A-Z0-9_]{3,15}`,
+	`[0-9]{4,12}`,
+}
+
+func matchesAll(s string, res []*regexp.Regexp) bool {
+	for _, re := range res {
+		if !re.MatchString(s) {
+			return false
+		}
+	}
+	return true
+}
 
-	faker.SetRandomSource(g.o.Rand)
+func matchesAny(s string, res []*regexp.Regexp) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
 
+func (g generator) string() string {
 	for _, c := range g.constraints {
 		switch c.Rule {
 		case RuleOneOf:
@@ -112,7 +189,7 @@ func (g generator) String() string {
 				s, _ := val.(string)
 				choices = append(choices, s)
 			}
-			i := g.o.Rand.Intn(len(choices))
+			i := g.o.rand.Intn(len(choices))
 			return choices[i]
 		case RuleEq:
 			return c.Value.(string)
@@ -122,65 +199,97 @@ func (g generator) String() string {
 			switch f {
 			case FormatUUID:
 				byt := make([]byte, 16)
-				g.o.Rand.Read(byt)
+				g.o.rand.Read(byt)
 				id, _ := uuid.NewRandomFromReader(bytes.NewBuffer(byt))
 				return id.String()
 			case FormatTitle:
-				parts := strings.Split(faker.Sentence(), " ")
-				if len(parts) > 4 {
-					return strings.Join(parts[:4], " ")
-				}
-				return strings.Join(parts, " ")
+				return strings.TrimSuffix(g.lorem().Sentence(2, 4), ".")
 			case FormatEmail:
-				return faker.Email()
+				return g.lorem().Email()
 			case FormatURL:
-				return faker.URL()
+				return g.lorem().Url()
 			case FormatName:
-				return faker.Name()
+				return g.lorem().FullName(lorem.RandomGender)
 			case FormatIPv4:
-				return faker.IPv4()
+				r := g.o.rand
+				return fmt.Sprintf("%d.%d.%d.%d", r.Intn(256), r.Intn(256), r.Intn(256), r.Intn(256))
 			case FormatIPv6:
-				return faker.IPv6()
+				groups := make([]string, 8)
+				for n := range groups {
+					groups[n] = fmt.Sprintf("%x", g.o.rand.Intn(1<<16))
+				}
+				return strings.Join(groups, ":")
 			case FormatDate:
-				return faker.Date()
+				return g.time().Format("2006-01-02")
 			case FormatPhone:
-				return faker.Phonenumber()
+				r := g.o.rand
+				return fmt.Sprintf("%03d-%03d-%04d", 200+r.Intn(800), r.Intn(1000), r.Intn(10000))
 			case FormatTime:
-				return time.Unix(faker.UnixTime(), 0).Format(time.RFC3339)
+				return g.time().Format(time.RFC3339)
+			}
+		case RuleRegex:
+			if s, err := regexString(g.o.rand, c.Value.(string)); err == nil {
+				return s
 			}
 		default:
 			// Other rules are not implemented
 		}
 	}
 
-	return lorem.New(g.o.Rand).Sentence(1, 20)
+	return g.lorem().Sentence(1, 20)
+}
+
+// lorem returns a text generator using the generator's random source, so that
+// text is reproducible without any package-level random state.
+func (g generator) lorem() *lorem.Lorem {
+	return lorem.New(g.o.rand)
+}
+
+// time returns a random time within maxTimeAge before Options.Now, or Epoch if
+// unset, so that times are reproducible.
+func (g generator) time() time.Time {
+	now := g.o.Now
+	if now.IsZero() {
+		now = Epoch
+	}
+	age := time.Duration(g.o.rand.Int63n(int64(maxTimeAge/time.Second))) * time.Second
+	return now.Add(-age).UTC()
 }
 
 func (g generator) Number() interface{} {
 	ne := []float64{}
+	timestamp := false
 
 	// Iterate through each rule and add a constraint
 	r := rng{o: g.o}
 	for _, c := range g.constraints {
 		switch c.Rule {
 		case RuleLT:
-			val := num(c.Value) - 1
-			r.max = &val
+			val := num(c.Value)
+			if g.kind == KindInt {
+				val--
+			}
+			r.max, r.maxExclusive = &val, g.kind != KindInt
 		case RuleLTE:
 			val := num(c.Value)
-			r.max = &val
+			r.max, r.maxExclusive = &val, false
 		case RuleGT:
-			val := num(c.Value) + 1
-			r.min = &val
+			val := num(c.Value)
+			if g.kind == KindInt {
+				val++
+			}
+			r.min, r.minExclusive = &val, g.kind != KindInt
 		case RuleGTE:
 			val := num(c.Value)
-			r.min = &val
+			r.min, r.minExclusive = &val, false
 		case RuleEq:
 			val := num(c.Value)
-			r.min = &val
-			r.max = &val
+			r.min, r.minExclusive = &val, false
+			r.max, r.maxExclusive = &val, false
 		case RuleNEq:
 			ne = append(ne, num(c.Value))
+		case RuleFormat:
+			timestamp = timestamp || c.Value == FormatTimestamp
 		case RuleOneOf:
 			r.choices = []float64{}
 			for _, val := range c.Value.([]interface{}) {
@@ -189,10 +298,24 @@ func (g generator) Number() interface{} {
 		}
 	}
 
-	// Generate a number that's not in this range.
+	// Timestamps are used unless they don't satisfy the constraints, eg. for
+	// a field named "created" which is an enum.
+	if timestamp && r.choices == nil {
+		f64 := float64(g.time().Unix())
+		if r.contains(f64) && !containsNumber(ne, f64) {
+			if g.kind == KindInt {
+				return int(f64)
+			}
+			return f64
+		}
+	}
+
+	// Generate a number that's not in this range.  Numbers which still
+	// don't satisfy the constraints after maxAttempts attempts are caught
+	// when validating the generated data.
 	var f64 float64
 	ok := false
-	for !ok {
+	for i := 0; !ok && i < maxAttempts; i++ {
 		f64 = r.generate()
 		ok = true
 		for _, item := range ne {
@@ -214,43 +337,87 @@ func (g generator) Number() interface{} {
 	return f64
 }
 
-// rng generates a number between [min, max] (inclusive).
+// rng generates a number between [min, max] (inclusive, unless exclusive).
 type rng struct {
 	o Options
 	// choices represent an enum of values, if specified.
 	choices []float64
-	// min is inclusive.
-	min *float64
-	// max is inclusive.
-	max *float64
+	// min is inclusive, unless minExclusive is set.
+	min          *float64
+	minExclusive bool
+	// max is inclusive, unless maxExclusive is set.
+	max          *float64
+	maxExclusive bool
 }
 
 // generate a random number usiong the given constraints.
 func (r rng) generate() float64 {
 	if len(r.choices) > 0 {
 		// Pick one of the choices at random.
-		i := r.o.Rand.Intn(len(r.choices))
+		i := r.o.rand.Intn(len(r.choices))
 		return r.choices[i]
 	}
 
 	min := float64(r.o.NumericBound) * -1
 	max := float64(r.o.NumericBound)
-	if r.min != nil && *r.min > min {
-		min = *r.min
+	if r.o.NonNegative && (r.max == nil || *r.max > 0) {
+		min = 0
 	}
-	if r.max != nil && *r.max < max {
-		max = *r.max
+	minExclusive, maxExclusive := false, false
+	if r.min != nil && *r.min >= min {
+		min, minExclusive = *r.min, r.minExclusive
+	}
+	if r.max != nil && *r.max <= max {
+		max, maxExclusive = *r.max, r.maxExclusive
+	}
+	within := func(f float64) bool {
+		if f < min || f > max {
+			return false
+		}
+		return !(minExclusive && f == min) && !(maxExclusive && f == max)
+	}
+
+	f := min + r.o.rand.Float64()*(max-min)
+
+	rounded := math.Round(f)
+	if r.o.FloatPrecision > 0 {
+		// Round to a specific decimal precision
+		precision := math.Pow10(r.o.FloatPrecision)
+		rounded = math.Round(f*precision) / precision
+	}
+	if within(rounded) {
+		return rounded
 	}
 
-	f := min + r.o.Rand.Float64()*(max-min)
-	if r.o.FloatPrecision == 0 {
-		// return the whole number with 0 precision.
-		return math.Round(f)
+	// Rounding may move the number outside of exclusive bounds, or bounds
+	// narrower than the precision, so use the number as-is, moving it past
+	// an exclusive minimum if necessary.
+	if !within(f) {
+		f = math.Nextafter(min, max)
 	}
+	return f
+}
 
-	// Round to a specific decimal precision
-	precision := math.Pow10(r.o.FloatPrecision)
-	return math.Round(f*precision) / precision
+// contains returns whether the number satisfies the range's bounds,
+// regardless of Options.NumericBound.
+func (r rng) contains(f float64) bool {
+	if r.min != nil && (f < *r.min || (r.minExclusive && f == *r.min)) {
+		return false
+	}
+	if r.max != nil && (f > *r.max || (r.maxExclusive && f == *r.max)) {
+		return false
+	}
+	return true
+}
+
+// containsNumber returns whether the list contains the number.
+func containsNumber(list []float64, f float64) bool {
+	for _, item := range list {
+		if item == f {
+			return true
+		}
+	}
+	return false
 }
 
 func num(i interface{}) float64 {
@@ -2,9 +2,7 @@ package fakedata
 
 import (
 	"context"
-	"math/rand"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +24,29 @@ func TestGenerateNumber(t *testing.T) {
 				require.True(t, i.(float64) <= 1.5)
 			},
 		},
+		{
+			kind: KindFloat,
+			rules: []Constraint{
+				{Rule: RuleGT, Value: 0.1},
+				{Rule: RuleLT, Value: 0.2},
+			},
+			check: func(i interface{}) {
+				require.True(t, i.(float64) > 0.1)
+				require.True(t, i.(float64) < 0.2)
+			},
+		},
+		{
+			// Bounds narrower than the float precision.
+			kind: KindFloat,
+			rules: []Constraint{
+				{Rule: RuleGT, Value: 0.101},
+				{Rule: RuleLT, Value: 0.102},
+			},
+			check: func(i interface{}) {
+				require.True(t, i.(float64) > 0.101)
+				require.True(t, i.(float64) < 0.102)
+			},
+		},
 		{
 			kind: KindInt,
 			rules: []Constraint{
@@ -65,8 +86,9 @@ func TestGenerateNumber(t *testing.T) {
 
 	for _, test := range tests {
 		for i := 0; i <= 100; i++ {
-			rand.Seed(time.Now().UnixNano())
-			val := Generate(context.Background(), test.kind, DefaultOptions, test.rules...)
+			o := DefaultOptions
+			o.Seed = int64(i)
+			val := Generate(context.Background(), test.kind, o, test.rules...)
 			test.check(val)
 		}
 	}
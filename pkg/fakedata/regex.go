@@ -0,0 +1,152 @@
+package fakedata
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxRepeat bounds the number of repetitions generated for unbounded
+	// repetition, eg. `a*`, `a+` or `a{2,}`.
+	maxRepeat = 8
+
+	// printableMin and printableMax bound the printable ASCII characters,
+	// which we prefer when generating characters from classes.
+	printableMin = 0x20
+	printableMax = 0x7e
+)
+
+// regexString generates a random string matching the given regular
+// expression, using the same syntax as cue (and Go).  Anchors such as `^` and
+// `$` generate nothing, so the string always matches the expression in full.
+func regexString(r *rand.Rand, pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+
+	sb := &strings.Builder{}
+	if err := genRegex(r, re.Simplify(), sb); err != nil {
+		return "", fmt.Errorf("error generating string for %q: %w", pattern, err)
+	}
+	return sb.String(), nil
+}
+
+func genRegex(r *rand.Rand, re *syntax.Regexp, sb *strings.Builder) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("expression never matches")
+
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		// These match positions rather than characters.
+
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && r.Intn(2) == 1 {
+				c = unicode.SimpleFold(c)
+			}
+			sb.WriteRune(c)
+		}
+
+	case syntax.OpCharClass:
+		c, ok := genClass(r, re.Rune)
+		if !ok {
+			return fmt.Errorf("empty character class")
+		}
+		sb.WriteRune(c)
+
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		sb.WriteRune(rune(printableMin + r.Intn(printableMax-printableMin+1)))
+
+	case syntax.OpCapture:
+		return genRegex(r, re.Sub[0], sb)
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := repeatBounds(re)
+		n := min + r.Intn(max-min+1)
+		for i := 0; i < n; i++ {
+			if err := genRegex(r, re.Sub[0], sb); err != nil {
+				return err
+			}
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := genRegex(r, sub, sb); err != nil {
+				return err
+			}
+		}
+
+	case syntax.OpAlternate:
+		return genRegex(r, re.Sub[r.Intn(len(re.Sub))], sb)
+
+	default:
+		return fmt.Errorf("unsupported operator: %s", re.Op)
+	}
+
+	return nil
+}
+
+// repeatBounds returns the inclusive bounds for the number of repetitions of
+// a repeating expression, bounding unbounded repetition by maxRepeat.
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, maxRepeat
+	case syntax.OpPlus:
+		return 1, maxRepeat
+	case syntax.OpQuest:
+		return 0, 1
+	}
+
+	if re.Max < 0 {
+		return re.Min, re.Min + maxRepeat
+	}
+	return re.Min, re.Max
+}
+
+// genClass returns a random character within the class's ranges, given as
+// pairs of inclusive bounds.  Printable ASCII characters are chosen whenever
+// the class contains them.
+func genClass(r *rand.Rand, ranges []rune) (rune, bool) {
+	printable := []rune{}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < printableMin {
+			lo = printableMin
+		}
+		if hi > printableMax {
+			hi = printableMax
+		}
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	}
+
+	// Choose a character uniformly across every range.
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	if total == 0 {
+		return 0, false
+	}
+
+	n := r.Intn(total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n), true
+		}
+		n -= size
+	}
+	return 0, false
+}
@@ -0,0 +1,91 @@
+package fakedata
+
+import (
+	"context"
+	"math/rand"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexString(t *testing.T) {
+	patterns := []string{
+		`^ch_[A-Za-z0-9]{24}$`,
+		`^(foo|bar)-\d+$`,
+		`^[^a-z]{3}$`,
+		`^\w+@\w+\.com$`,
+		`^(?i)abc$`,
+		`^a*b+c?d{2,}$`,
+		`^.{5}$`,
+		`^[\p{Greek}]+$`,
+		`^(|x)y$`,
+		`\bword\b`,
+		`^v\d+\.\d+\.\d+(-rc\.\d+)?$`,
+	}
+
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			re := regexp.MustCompile(pattern)
+			for i := 0; i < 100; i++ {
+				s, err := regexString(rand.New(rand.NewSource(int64(i))), pattern)
+				require.NoError(t, err)
+				require.True(t, utf8.ValidString(s))
+				require.True(t, re.MatchString(s), "%q doesn't match %s", s, pattern)
+			}
+		})
+	}
+
+	// Unbounded repetition is bounded.
+	for i := 0; i < 100; i++ {
+		s, err := regexString(rand.New(rand.NewSource(int64(i))), `^a+$`)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(s), maxRepeat)
+	}
+
+	_, err := regexString(rand.New(rand.NewSource(1)), `^[a-`)
+	require.Error(t, err)
+}
+
+func TestGenerateStringRegex(t *testing.T) {
+	o := DefaultOptions
+	for i := 0; i < 100; i++ {
+		o.Seed = int64(i)
+
+		// Strings must match every regular expression.
+		s := Generate(context.Background(), KindString, o,
+			Constraint{Rule: RuleRegex, Value: `^[a-z]{1,3}$`},
+			Constraint{Rule: RuleRegex, Value: `a`},
+		).(string)
+		require.Regexp(t, `^[a-z]{1,3}$`, s)
+		require.Contains(t, s, "a")
+
+		// Strings matching a negated regular expression are rejected.
+		s = Generate(context.Background(), KindString, o,
+			Constraint{Rule: RuleRegex, Value: `^[ab]{2}$`},
+			Constraint{Rule: RuleNotRegex, Value: `^aa$`},
+		).(string)
+		require.Regexp(t, `^[ab]{2}$`, s)
+		require.NotEqual(t, "aa", s)
+
+		s = Generate(context.Background(), KindString, o,
+			Constraint{Rule: RuleFormat, Value: FormatEmail},
+			Constraint{Rule: RuleNotRegex, Value: `\.com$`},
+		).(string)
+		require.NotRegexp(t, `\.com$`, s)
+
+		// Strings fall back to other generators when the constraint's
+		// generator can't satisfy a negated regular expression.
+		s = Generate(context.Background(), KindString, o,
+			Constraint{Rule: RuleNotRegex, Value: `^[A-Z]`},
+		).(string)
+		require.NotRegexp(t, `^[A-Z]`, s)
+
+		s = Generate(context.Background(), KindString, o,
+			Constraint{Rule: RuleFormat, Value: FormatName},
+			Constraint{Rule: RuleNotRegex, Value: `^[A-Za-z]`},
+		).(string)
+		require.NotRegexp(t, `^[A-Za-z]`, s)
+	}
+}
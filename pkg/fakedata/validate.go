@@ -0,0 +1,166 @@
+package fakedata
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+)
+
+// FieldError describes a field for which we couldn't generate fake data, or
+// whose fake data doesn't satisfy the field's definition.
+type FieldError struct {
+	// Path is the dot-separated path of the field, eg. "data.id".  This is
+	// empty for errors with the value as a whole.
+	Path string
+	// Err is the reason the field is invalid.
+	Err error
+
+	// segments are the labels within the path.
+	segments []string
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when fake data doesn't satisfy its definition
+// after regenerating the invalid fields Options.MaxRetries times.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for n, f := range e.Fields {
+		msgs[n] = f.Error()
+	}
+	return fmt.Sprintf("unable to generate valid fake data: %s", strings.Join(msgs, "; "))
+}
+
+// validate unifies the fake data with its definition, returning each field
+// whose data doesn't satisfy the definition.
+func validate(schema, val cue.Value) []*FieldError {
+	err := schema.Unify(val).Validate()
+	if err == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	fields := []*FieldError{}
+	for _, e := range errors.Errors(err) {
+		segments := e.Path()
+		path := strings.Join(segments, ".")
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		format, args := e.Msg()
+		fields = append(fields, &FieldError{
+			Path:     path,
+			Err:      fmt.Errorf(format, args...),
+			segments: segments,
+		})
+	}
+	return fields
+}
+
+// regenerate regenerates the fake data for the field at the given path within
+// the output struct.  Fields which can't be regenerated individually, such as
+// list elements or fields within disjunctions, are regenerated via their
+// closest ancestor.
+func regenerate(ctx context.Context, schema cue.Value, to *ast.StructLit, segments []string, o Options) error {
+	if len(segments) == 0 {
+		to.Elts = nil
+		return walk(ctx, schema, to, o)
+	}
+
+	label := segments[0]
+	val, ok := lookupField(schema, label)
+	if !ok {
+		to.Elts = nil
+		return walk(ctx, schema, to, o)
+	}
+
+	nestedCtx := withPath(ctx, label)
+	field := findField(to, label)
+
+	// Recurse into structs, unless the next label is a list index.
+	if field != nil && len(segments) > 1 {
+		inner, isStruct := field.Value.(*ast.StructLit)
+		if _, err := strconv.Atoi(segments[1]); err != nil && isStruct {
+			return regenerate(nestedCtx, val, inner, segments[1:], o)
+		}
+	}
+
+	expr, ok, err := fake(nestedCtx, val, o)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !ok:
+		removeField(to, label)
+	case field == nil:
+		set(to, label, expr)
+	default:
+		field.Value = expr
+	}
+	return nil
+}
+
+// lookupField returns the definition of a regular or optional field within a
+// struct.
+func lookupField(v cue.Value, label string) (cue.Value, bool) {
+	if op, _ := v.Expr(); op == cue.OrOp {
+		// Fields within disjunctions depend on the disjunct.
+		return cue.Value{}, false
+	}
+
+	it, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return cue.Value{}, false
+	}
+	for it.Next() {
+		if it.Label() == label {
+			return it.Value(), true
+		}
+	}
+	return cue.Value{}, false
+}
+
+// findField returns the field with the given label within a cue struct.
+func findField(s *ast.StructLit, label string) *ast.Field {
+	for _, elt := range s.Elts {
+		f, ok := elt.(*ast.Field)
+		if !ok {
+			continue
+		}
+		if name, _, err := ast.LabelName(f.Label); err == nil && name == label {
+			return f
+		}
+	}
+	return nil
+}
+
+// removeField removes the field with the given label from a cue struct.
+func removeField(s *ast.StructLit, label string) {
+	f := findField(s, label)
+	for n, elt := range s.Elts {
+		if elt == f {
+			s.Elts = append(s.Elts[:n], s.Elts[n+1:]...)
+			return
+		}
+	}
+}
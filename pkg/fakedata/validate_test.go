@@ -0,0 +1,123 @@
+package fakedata
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeRegeneratesInvalidFields(t *testing.T) {
+	// Booleans ignore their constraints when generated, so are invalid
+	// half of the time until regenerated.
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		enabled: true
+		data: { disabled: false, count: int & >= 1 & <= 3 }
+		something: { ok: true } | { fine: true }
+	}`)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		output, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+
+		mapped := map[string]interface{}{}
+		require.NoError(t, output.Decode(&mapped))
+		require.Equal(t, true, mapped["enabled"])
+		require.Equal(t, false, mapped["data"].(map[string]interface{})["disabled"])
+		require.Len(t, mapped["something"], 1)
+	}
+}
+
+func TestFakeNegatedRegex(t *testing.T) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		x:    string & !~"^[A-Z]"
+		name: string & !~"^[A-Z]"
+	}`)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		_, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+	}
+}
+
+func TestFakeZeroFloatBounds(t *testing.T) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		a: number & >=0
+		b: float & >0 & <1
+		c: float & !=0
+		d: float & >=0.0
+	}`)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		_, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+	}
+}
+
+func TestFakeExclusiveFloatBounds(t *testing.T) {
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{ f: float & >0.1 & <0.2, g: float & >0.101 & <0.102 }`)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		o := DefaultOptions
+		o.Seed = int64(i)
+		o.MaxRetries = 0
+		_, err := FakeWithOptions(context.Background(), inst.Value(), o)
+		require.NoError(t, err)
+	}
+}
+
+func TestFakeValidationError(t *testing.T) {
+	// Strings must both match and not match the expression, so can never
+	// satisfy the definition.
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		name: "foo"
+		data: { email: string & =~"^[0-9]+$" & !~"^[0-9]+$" }
+	}`)
+	require.NoError(t, err)
+
+	o := DefaultOptions
+	o.MaxRetries = 3
+	_, err = FakeWithOptions(context.Background(), inst.Value(), o)
+	require.Error(t, err)
+
+	verr := &ValidationError{}
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Fields, 1)
+	require.Equal(t, "data.email", verr.Fields[0].Path)
+	require.Contains(t, err.Error(), "data.email: invalid value")
+}
+
+func TestFakeFieldError(t *testing.T) {
+	// Constraints referencing other fields can't be decoded, which returns
+	// an error rather than panicking.
+	r := &cue.Runtime{}
+	inst, err := r.Compile(".", `{
+		a: int
+		b: int & > a
+	}`)
+	require.NoError(t, err)
+
+	_, err = Fake(context.Background(), inst.Value())
+	require.Error(t, err)
+
+	ferr := &FieldError{}
+	require.True(t, errors.As(err, &ferr))
+	require.Equal(t, "b", ferr.Path)
+}
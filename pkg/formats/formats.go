@@ -0,0 +1,88 @@
+// Package formats recognizes well-known string formats, such as timestamps,
+// UUIDs and emails, along with the regular expressions which constrain cue
+// strings to each format.
+//
+// Inferring types from JSON uses this package to constrain strings, and
+// generating fake data uses this package to recognize the constraints.
+package formats
+
+import (
+	"net"
+	"regexp"
+	"time"
+)
+
+// Format is a well-known string format.  Each format is named after the
+// equivalent JSON schema format.
+type Format string
+
+const (
+	DateTime Format = "date-time"
+	UUID     Format = "uuid"
+	Email    Format = "email"
+	URL      Format = "uri"
+	IPv4     Format = "ipv4"
+	IPv6     Format = "ipv6"
+)
+
+// All lists every format in the order in which they're detected.  Earlier
+// formats are more specific than later formats.
+var All = []Format{DateTime, UUID, URL, Email, IPv4, IPv6}
+
+var patterns = map[Format]string{
+	DateTime: `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`,
+	UUID:     `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	Email:    `^[^@\s]+@[^@\s]+\.[^@\s]+$`,
+	URL:      `^https?://[^\s/$.?#][^\s]*$`,
+	IPv4:     `^((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)$`,
+	IPv6:     `^[0-9a-fA-F]{0,4}(:[0-9a-fA-F]{0,4}){2,7}$`,
+}
+
+var compiled = map[Format]*regexp.Regexp{}
+
+func init() {
+	for f, p := range patterns {
+		compiled[f] = regexp.MustCompile(p)
+	}
+}
+
+// Pattern returns the regular expression matching the given format, or an
+// empty string if the format is unknown.
+func Pattern(f Format) string {
+	return patterns[f]
+}
+
+// FromPattern returns the format matched by the given regular expression, if
+// the expression is one of the patterns returned by Pattern.
+func FromPattern(pattern string) (Format, bool) {
+	for f, p := range patterns {
+		if p == pattern {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Detect returns the format of the given string, if the string is in one of
+// the well-known formats.
+func Detect(s string) (Format, bool) {
+	for _, f := range All {
+		if compiled[f].MatchString(s) && valid(f, s) {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// valid checks strings which match a format's pattern but may still be
+// invalid, eg. the 31st of February or "12:30:45" as an IPv6 address.
+func valid(f Format, s string) bool {
+	switch f {
+	case DateTime:
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case IPv4, IPv6:
+		return net.ParseIP(s) != nil
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package formats
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := map[string]Format{
+		"2022-05-01T10:00:00Z":                 DateTime,
+		"2022-05-01T10:00:00.123+01:00":        DateTime,
+		"7c9e6679-7425-40de-944b-e07fc1f90ae7": UUID,
+		"https://example.com/a?b=c":            URL,
+		"jane@example.com":                     Email,
+		"10.0.0.1":                             IPv4,
+		"2001:db8::68":                         IPv6,
+		"::1":                                  IPv6,
+	}
+	for input, expected := range tests {
+		actual, ok := Detect(input)
+		require.True(t, ok, input)
+		require.Equal(t, expected, actual, input)
+
+		// Every detected string must match the format's pattern.
+		require.Regexp(t, regexp.MustCompile(Pattern(actual)), input)
+	}
+
+	for _, input := range []string{"", "hello", "2022-02-31T10:00:00Z", "12:30:45", "999.0.0.1", "example.com"} {
+		_, ok := Detect(input)
+		require.False(t, ok, input)
+	}
+}
+
+func TestFromPattern(t *testing.T) {
+	for _, f := range All {
+		actual, ok := FromPattern(Pattern(f))
+		require.True(t, ok)
+		require.Equal(t, f, actual)
+	}
+
+	_, ok := FromPattern("^[a-z]+$")
+	require.False(t, ok)
+}
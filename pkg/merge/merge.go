@@ -35,17 +35,6 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 	// Build a new struct which will contain merged fields from A and B.
 	def := &ast.StructLit{}
 
-	// Iterate through A, grabbing fields from B.  We'll compare each field in
-	// A to the fields in B, merging the values together.
-	it, err := a.Fields(
-		cue.All(),
-		cue.Definitions(true),
-		cue.Concrete(false),
-	)
-	if err != nil {
-		return cue.Value{}, fmt.Errorf("error returning fields of a: %w", err)
-	}
-
 	// The general strategy is to:
 	//
 	// 1. Store a list of values - referring to type defintions - in A and B
@@ -60,43 +49,51 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 	// field and merge the definitions together.  This is a special case.
 	//
 	// If we're merging slices together, we always create a binary op.
+	//
+	// Walk through A, grabbing fields from B, followed by fields only in B.  We'll
+	// compare each field in A to the fields in B, merging the values together.
+	err := WalkFields(a, b, func(label string, aField, bField cue.FieldInfo) error {
+		aValue := aField.Value
+		bValue := bField.Value
 
-	for it.Next() {
-		label := it.Label()
-
-		aValue := it.Value()
+		if aValue.IncompleteKind() == cue.BottomKind {
+			// This field isn't present in A, so we can add this directly to
+			// our struct.  Mark this field as optional as it's only usable in
+			// one of the definitions.
+			field := bValue.Source().(*ast.Field)
+			field.Optional = token.Blank.Pos()
+			def.Elts = append(def.Elts, field)
+			return nil
+		}
 
 		if aValue.Source() == nil {
-			continue
+			return nil
 		}
 
 		// Get the AST for the field in A, which is always an *ast.Field.
 		aValAsField := aValue.Source().(*ast.Field)
 
-		// Look the field up to check whether it's a member of the second struct B.
-		// If it isn't found on the other struct, we can safely use A's field as-is.
-		bField, _ := b.FieldByName(label, true)
-		bValue := bField.Value
-
+		// If the field isn't found on the other struct B, we can safely use A's
+		// field as-is.
 		if bValue.IncompleteKind() == cue.BottomKind {
 			// Use A immediately, as there is no field in B.  Mark this field as
 			// optional as it's only usable in one of the definitions.
 			aValAsField.Optional = token.Blank.Pos()
 			def.Elts = append(def.Elts, aValAsField)
-			continue
+			return nil
 		}
 
 		bValAsField := bValue.Source().(*ast.Field)
 
 		// Get all values for the field, expanding the binary tree of unions into a
 		// list of vales.
-		aValues, err := expandValues(r, aValue)
+		aValues, err := ExpandValues(r, aValue)
 		if err != nil {
-			return cue.Value{}, err
+			return err
 		}
-		bValues, err := expandValues(r, bValue)
+		bValues, err := ExpandValues(r, bValue)
 		if err != nil {
-			return cue.Value{}, err
+			return err
 		}
 
 		// If we have one value each - and they're both structs - we need to recursively
@@ -116,7 +113,7 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 					Label: ast.NewIdent(label),
 					Value: union(aValAsField.Value, bValAsField.Value),
 				})
-				continue
+				return nil
 			}
 
 			// If the values are of the same scalar kind, use the values from A.
@@ -126,7 +123,7 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 				//
 				// The fields are the same scalar kind, so we can continue.
 				def.Elts = append(def.Elts, aValAsField)
-				continue
+				return nil
 			}
 
 			// If we're merging two structs together, recursively merge each member.
@@ -135,14 +132,14 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 				// two struct fields together into a new struct.
 				next, err := recursivelyMerge(ctx, aValue, bValue)
 				if err != nil {
-					return cue.Value{}, err
+					return err
 				}
 
 				switch src := next.Source().(type) {
 				case *ast.Field:
 					// We're returned an *ast.Field directly
 					def.Elts = append(def.Elts, src)
-					continue
+					return nil
 				case *ast.File:
 					// This is an *ast.File, as it's entirely formatted by cueutil.ASTToValue.
 					// Pull put the declaration from the file.
@@ -152,11 +149,11 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 						Value: expr,
 					})
 				default:
-					return cue.Value{}, fmt.Errorf("unknown source kind for struct: %T", src)
+					return fmt.Errorf("unknown source kind for struct: %T", src)
 				}
 
 				// Continue on to the next field
-				continue
+				return nil
 			}
 
 			// We're only left with merging slices.  This uses the same logic as merging >
@@ -171,7 +168,7 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 		for _, item := range append(aValues, bValues...) {
 			code, err := cueutil.ASTToSyntax(item.Source())
 			if err != nil {
-				return cue.Value{}, err
+				return err
 			}
 
 			if _, ok := seen[code]; ok {
@@ -186,7 +183,7 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 			case *ast.File:
 				deduped = append(deduped, src.Decls[0].(*ast.EmbedDecl).Expr)
 			default:
-				return cue.Value{}, fmt.Errorf("unknown ast type deduplicating value: %T", src)
+				return fmt.Errorf("unknown ast type deduplicating value: %T", src)
 			}
 		}
 
@@ -195,32 +192,10 @@ func recursivelyMerge(ctx context.Context, a, b cue.Value) (cue.Value, error) {
 			Label: ast.NewIdent(label),
 			Value: union(deduped...),
 		})
-		continue
-	}
-
-	// We may have missed fields from B that were missing in A.  Iterate through all of B's
-	// fields and check which ones haven't been merged.
-	it, err = b.Fields(
-		cue.All(),
-		cue.Definitions(true),
-		cue.Concrete(false),
-	)
+		return nil
+	})
 	if err != nil {
-		return cue.Value{}, fmt.Errorf("error returning fields for b: %w", err)
-	}
-	for it.Next() {
-		label := it.Label()
-		if a.LookupPath(cue.ParsePath(label)).IncompleteKind() != cue.BottomKind {
-			// This field was found;  skip.
-			continue
-		}
-
-		// This field isn't present in A, so it was skipped.  We can add this directly
-		// to our struct.
-		val := it.Value()
-		field := val.Source().(*ast.Field)
-		field.Optional = token.Blank.Pos()
-		def.Elts = append(def.Elts, field)
+		return cue.Value{}, err
 	}
 
 	return cueutil.ASTToValue(r, def)
@@ -255,21 +230,6 @@ func union(elts ...ast.Expr) ast.Expr {
 	return current
 }
 
-func expandValues(r *cue.Runtime, union cue.Value) ([]cue.Value, error) {
-	if union, ok := union.Syntax().(*ast.BinaryExpr); ok {
-		vals := []cue.Value{}
-		for _, expr := range expand(union) {
-			val, err := cueutil.ASTToValue(r, expr)
-			if err != nil {
-				return vals, err
-			}
-			vals = append(vals, val)
-		}
-		return vals, nil
-	}
-	return []cue.Value{union}, nil
-}
-
 // expand walks a BinaryExpr, returning every non-binary expr as a single slice.
 func expand(union *ast.BinaryExpr) []ast.Expr {
 	result := []ast.Expr{}
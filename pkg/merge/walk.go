@@ -0,0 +1,97 @@
+package merge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"github.com/inngest/event-schemas/pkg/cueutil"
+)
+
+// FieldFunc is called for each field when walking two structs.  If the field
+// only exists within one of the structs, the other FieldInfo's Value has a
+// BottomKind.
+type FieldFunc func(label string, a, b cue.FieldInfo) error
+
+// WalkFields calls fn for every field within the structs a and b, including
+// optional fields and definitions.  Fields are visited in the order they're
+// defined within a, followed by any fields which only exist within b.
+func WalkFields(a, b cue.Value, fn FieldFunc) error {
+	seen := map[string]bool{}
+
+	if a.IncompleteKind() != cue.BottomKind {
+		it, err := a.Fields(
+			cue.All(),
+			cue.Definitions(true),
+			cue.Concrete(false),
+		)
+		if err != nil {
+			return fmt.Errorf("error returning fields of a: %w", err)
+		}
+		for it.Next() {
+			label := it.Label()
+			seen[label] = true
+
+			// Look the field up to check whether it's a member of the second
+			// struct B.  If not, the field's value is BottomKind.
+			var bField cue.FieldInfo
+			if b.IncompleteKind() != cue.BottomKind {
+				bField, _ = b.FieldByName(label, true)
+			}
+			if err := fn(label, fieldInfo(it), bField); err != nil {
+				return err
+			}
+		}
+	}
+
+	if b.IncompleteKind() == cue.BottomKind {
+		return nil
+	}
+
+	// Iterate through all of B's fields, visiting those which aren't in A.
+	it, err := b.Fields(
+		cue.All(),
+		cue.Definitions(true),
+		cue.Concrete(false),
+	)
+	if err != nil {
+		return fmt.Errorf("error returning fields for b: %w", err)
+	}
+	for it.Next() {
+		if seen[it.Label()] {
+			continue
+		}
+		if err := fn(it.Label(), cue.FieldInfo{}, fieldInfo(it)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fieldInfo(it *cue.Iterator) cue.FieldInfo {
+	return cue.FieldInfo{
+		Selector:     it.Selector().String(),
+		Name:         it.Label(),
+		Value:        it.Value(),
+		IsDefinition: it.IsDefinition(),
+		IsOptional:   it.IsOptional(),
+		IsHidden:     it.IsHidden(),
+	}
+}
+
+// ExpandValues returns each member of a union, or a single item containing
+// the value if the value isn't a union.
+func ExpandValues(r *cue.Runtime, union cue.Value) ([]cue.Value, error) {
+	if union, ok := union.Syntax().(*ast.BinaryExpr); ok {
+		vals := []cue.Value{}
+		for _, expr := range expand(union) {
+			val, err := cueutil.ASTToValue(r, expr)
+			if err != nil {
+				return vals, err
+			}
+			vals = append(vals, val)
+		}
+		return vals, nil
+	}
+	return []cue.Value{union}, nil
+}